@@ -0,0 +1,352 @@
+// Command openapi generates the OpenAPI 3 description of the REST gateway
+// from user_service.proto and the gateway route table, writing
+// docs/swagger.json, docs/swagger.yaml and docs/api.md.
+//
+//	go run ./cmd/openapi
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/route"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+type object = map[string]interface{}
+
+func main() {
+	out := flag.String("out", "docs", "directory the documents are written to")
+	flag.Parse()
+
+	g := &generator{schemas: object{}}
+	spec := g.spec()
+
+	jsonDoc, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var yamlDoc bytes.Buffer
+	enc := yaml.NewEncoder(&yamlDoc)
+	enc.SetIndent(2)
+	if err := enc.Encode(spec); err != nil {
+		log.Fatal(err)
+	}
+
+	files := map[string][]byte{
+		"swagger.json": append(jsonDoc, '\n'),
+		"swagger.yaml": yamlDoc.Bytes(),
+		"api.md":       []byte(g.markdown()),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(*out, name), content, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+type generator struct {
+	schemas object
+}
+
+func (g *generator) service() protoreflect.ServiceDescriptor {
+	return pb.File_internal_grpc_user_service_proto.Services().ByName("UserService")
+}
+
+func (g *generator) method(fullMethod string) protoreflect.MethodDescriptor {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	return g.service().Methods().ByName(protoreflect.Name(name))
+}
+
+func (g *generator) spec() object {
+	paths := object{}
+
+	for _, e := range route.Endpoints() {
+		method := g.method(e.FullMethod)
+
+		operation := object{
+			"operationId": string(method.Name()),
+			"summary":     fmt.Sprintf("Calls the %s RPC", method.Name()),
+			"tags":        []string{tag(e.Path)},
+			"responses": object{
+				"200": object{
+					"description": "Successful response",
+					"content":     jsonContent(g.messageRef(method.Output())),
+				},
+				"default": object{
+					"description": "Error mapped from the gRPC status code",
+					"content":     jsonContent(object{"$ref": "#/components/schemas/Error"}),
+				},
+			},
+		}
+
+		if e.Download {
+			operation["responses"].(object)["200"] = object{
+				"description": "The file, sent as an attachment",
+				"content": object{
+					"text/csv":        object{"schema": object{"type": "string"}},
+					"application/pdf": object{"schema": object{"type": "string", "format": "binary"}},
+				},
+			}
+		}
+
+		var parameters []object
+		pathParams := map[string]bool{}
+		for _, segment := range strings.Split(e.Path, "/") {
+			if strings.HasPrefix(segment, "{") {
+				name := strings.Trim(segment, "{}")
+				pathParams[name] = true
+				parameters = append(parameters, object{
+					"name":     name,
+					"in":       "path",
+					"required": true,
+					"schema":   object{"type": "integer", "format": "uint64", "minimum": 1},
+				})
+			}
+		}
+		if e.Method == "GET" || e.Method == "DELETE" {
+			fields := method.Input().Fields()
+			for i := 0; i < fields.Len(); i++ {
+				fd := fields.Get(i)
+				if contains(e.BoundFields, string(fd.Name())) || fd.IsList() || fd.IsMap() {
+					continue
+				}
+				parameters = append(parameters, object{
+					"name":   string(fd.Name()),
+					"in":     "query",
+					"schema": g.singularSchema(fd),
+				})
+			}
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		if e.Method != "GET" && e.Method != "DELETE" {
+			body := g.messageRef(method.Input())
+			if len(e.BoundFields) > 0 {
+				body = g.messageSchema(method.Input(), e.BoundFields)
+			}
+			operation["requestBody"] = object{
+				"required": true,
+				"content":  jsonContent(body),
+			}
+		}
+
+		if e.Auth {
+			operation["security"] = []object{{"bearerAuth": []string{}}}
+			for _, field := range e.BoundFields {
+				if !pathParams[field] {
+					operation["description"] = fmt.Sprintf("`%s` is the ID of the authenticated caller.", field)
+				}
+			}
+		}
+
+		item, _ := paths[e.Path].(object)
+		if item == nil {
+			item = object{}
+			paths[e.Path] = item
+		}
+		item[strings.ToLower(e.Method)] = operation
+	}
+
+	g.schemas["Error"] = object{
+		"type": "object",
+		"properties": object{
+			"error": object{
+				"type": "object",
+				"properties": object{
+					"code":     object{"type": "string", "description": "gRPC status code name, e.g. INVALID_ARGUMENT"},
+					"message":  object{"type": "string"},
+					"reason":   object{"type": "string", "description": "Stable machine readable reason, e.g. EMAIL_TAKEN"},
+					"metadata": object{"type": "object", "additionalProperties": object{"type": "string"}},
+					"field_violations": object{
+						"type": "array",
+						"items": object{
+							"type": "object",
+							"properties": object{
+								"field":       object{"type": "string"},
+								"description": object{"type": "string"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":       "EcoTaxi User Service",
+			"version":     "1.0.0",
+			"description": "REST mirror of the user_service.UserService gRPC API. 64-bit integers are encoded as strings in responses, following the protobuf JSON mapping.",
+		},
+		"servers": []object{{"url": "/"}},
+		"paths":   paths,
+		"components": object{
+			"schemas": g.schemas,
+			"securitySchemes": object{
+				"bearerAuth": object{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+					"description":  "Access token returned by /v1/auth/login. gRPC callers send it as `authorization: Bearer <token>` metadata.",
+				},
+			},
+		},
+	}
+}
+
+func (g *generator) messageRef(md protoreflect.MessageDescriptor) object {
+	if schema := wellKnownSchema(md); schema != nil {
+		return schema
+	}
+
+	name := string(md.Name())
+	if _, ok := g.schemas[name]; !ok {
+		// Reserve the name first so recursive messages terminate
+		g.schemas[name] = object{}
+		g.schemas[name] = g.messageSchema(md, nil)
+	}
+	return object{"$ref": "#/components/schemas/" + name}
+}
+
+func (g *generator) messageSchema(md protoreflect.MessageDescriptor, skip []string) object {
+	properties := object{}
+	fields := md.Fields()
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if contains(skip, string(fd.Name())) {
+			continue
+		}
+		properties[string(fd.Name())] = g.fieldSchema(fd)
+	}
+
+	return object{"type": "object", "properties": properties}
+}
+
+func (g *generator) fieldSchema(fd protoreflect.FieldDescriptor) object {
+	if fd.IsMap() {
+		return object{"type": "object", "additionalProperties": g.singularSchema(fd.MapValue())}
+	}
+	if fd.IsList() {
+		return object{"type": "array", "items": g.singularSchema(fd)}
+	}
+	return g.singularSchema(fd)
+}
+
+func (g *generator) singularSchema(fd protoreflect.FieldDescriptor) object {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return object{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return object{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return object{"type": "integer", "format": "uint32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return object{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return object{"type": "string", "format": "uint64"}
+	case protoreflect.FloatKind:
+		return object{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return object{"type": "number", "format": "double"}
+	case protoreflect.StringKind:
+		return object{"type": "string"}
+	case protoreflect.BytesKind:
+		return object{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		var names []string
+		values := fd.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		return object{"type": "string", "enum": names}
+	default:
+		return g.messageRef(fd.Message())
+	}
+}
+
+// Schemas of the well-known types, following their protobuf JSON mapping
+func wellKnownSchema(md protoreflect.MessageDescriptor) object {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		return object{"type": "string", "format": "date-time"}
+	case "google.protobuf.Duration":
+		return object{"type": "string", "example": "3.5s"}
+	case "google.protobuf.FieldMask":
+		return object{"type": "string", "description": "Comma separated field names", "example": "name,email"}
+	}
+	return nil
+}
+
+func (g *generator) markdown() string {
+	var b strings.Builder
+
+	b.WriteString("# User Service REST API\n\n")
+	b.WriteString("Generated by `make docs` from `internal/grpc/user_service.proto` and the gateway routes in `internal/route`. Do not edit by hand.\n\n")
+	b.WriteString("The OpenAPI document is served at `/swagger/doc.json` (and `/swagger/doc.yaml`), with a Swagger UI at `/swagger`.\n\n")
+	b.WriteString("| Method | Path | RPC | Auth |\n")
+	b.WriteString("| ------ | ---- | --- | ---- |\n")
+
+	endpoints := route.Endpoints()
+	sort.SliceStable(endpoints, func(i, j int) bool { return endpoints[i].Path < endpoints[j].Path })
+	for _, e := range endpoints {
+		auth := ""
+		if e.Auth {
+			auth = "Bearer"
+		}
+		fmt.Fprintf(&b, "| `%s` | `%s` | `%s` | %s |\n", e.Method, e.Path, g.method(e.FullMethod).Name(), auth)
+	}
+
+	b.WriteString("\nErrors are returned with the HTTP status matching the gRPC status code:\n\n")
+	b.WriteString("```json\n")
+	b.WriteString(errorExample)
+	b.WriteString("```\n")
+
+	return b.String()
+}
+
+const errorExample = `{
+  "error": {
+    "code": "INVALID_ARGUMENT",
+    "message": "Missing required fields",
+    "reason": "INVALID_REQUEST",
+    "field_violations": [{ "field": "email", "description": "is required" }]
+  }
+}
+`
+
+func jsonContent(schema object) object {
+	return object{"application/json": object{"schema": schema}}
+}
+
+// Groups operations by the first path segment after the version
+func tag(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 {
+		return "default"
+	}
+	return segments[1]
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
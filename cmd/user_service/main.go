@@ -4,16 +4,25 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
+	// Users' time zones must load in images without a zoneinfo database, e.g. alpine
+	_ "time/tzdata"
 
 	// emailverifier "github.com/AfterShip/email-verifier"
 	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
 	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/health"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/job"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/lifecycle"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/route"
 	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/service"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/validation"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
 )
 
 // var (
@@ -21,51 +30,72 @@ import (
 // )
 
 func main() {
-	loadEnv()
-	r := gin.Default()
-	log.Println("Starting User Service")
-	
-	
-	if err := config.ConnectToMySQL(); err != nil {
-		log.Panic("Failed to connect to MySQL:", err)
+	if err := run(); err != nil {
+		log.Println(err)
+		os.Exit(1)
 	}
+}
 
-	
-	if err := config.ConnectToRedis(); err != nil {
-		log.Panic("Failed to connect to Redis:", err)
+func run() error {
+	cfg, err := config.LoadConfig(os.Args[1:])
+	if err != nil {
+		return err
 	}
 
-	go listenGRPC()
+	log.Println("Starting User Service")
+	manager := lifecycle.NewManager(cfg.ShutdownTimeout, cfg.ShutdownDrainDelay)
 
-	
-	if err := r.Run(fmt.Sprintf(":%s", os.Getenv("PORT"))); err != nil {
-		log.Panic(err)
+	if err := config.ConnectToMySQL(cfg.MySQL); err != nil {
+		return fmt.Errorf("failed to connect to MySQL: %w", err)
 	}
-	
-	// r.Run() // listen and serve on 0.0.0.0:8082 (for windows "localhost:8082")
-}
+	manager.AddCloser("MySQL", config.CloseMySQL)
+
+	if err := config.ConnectToRedis(cfg.Redis); err != nil {
+		manager.Close()
+		return fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	manager.AddCloser("Redis", config.CloseRedis)
 
-func loadEnv() {
-	err := godotenv.Load("app.env")
+	// Both ports are bound before anything is served so a startup failure
+	// never leaves one server running without the other
+	grpcLis, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPC.Port))
 	if err != nil {
-		log.Fatal("Error loading .env file")
+		manager.Close()
+		return fmt.Errorf("failed to listen for gRPC User Service: %w", err)
 	}
-}
 
-func listenGRPC() {
-	gRPCPort := os.Getenv("GRPC_PORT")
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", gRPCPort))
+	httpLis, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.Port))
 	if err != nil {
-		log.Fatalf("Failed to listen for gRPC User Service: %v", err)
+		grpcLis.Close()
+		manager.Close()
+		return fmt.Errorf("failed to listen for HTTP User Service: %w", err)
 	}
 
-	s := grpc.NewServer()
+	checker := health.NewChecker(cfg.Health, cfg.SMTP)
+	manager.AddShutdownHook(checker.SetNotServing)
+	manager.AddServer(checker)
+	manager.AddServer(job.NewPointsExpiry(cfg.Points))
+	manager.AddServer(job.NewTierEvaluation(cfg.Tier))
+	manager.AddServer(job.NewLeaderboardRebuild(cfg.Leaderboard))
+	manager.AddServer(job.NewStreakReminder(cfg.Goals))
 
-	pb.RegisterUserServiceServer(s, &service.UserServiceServer{})
+	userServer := &service.UserServiceServer{}
+	interceptors := []grpc.UnaryServerInterceptor{
+		apperror.UnaryServerInterceptor,
+		service.AuthUnaryInterceptor,
+		validation.UnaryServerInterceptor,
+	}
 
-	log.Printf("gRPC User Service server started on port %s", gRPCPort)
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+	pb.RegisterUserServiceServer(s, userServer)
+	healthpb.RegisterHealthServer(s, checker.HealthServer())
+	manager.AddServer(lifecycle.NewGRPCServer(s, grpcLis))
 
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("Failed to listen for gRPC User Service server: %v", err)
-	}
-}
\ No newline at end of file
+	r := gin.Default()
+	route.RegisterHealthRoutes(r, checker)
+	route.RegisterUserRoutes(r, userServer, interceptors...)
+	route.RegisterSwaggerRoutes(r)
+	manager.AddServer(lifecycle.NewHTTPServer(&http.Server{Handler: r}, httpLis))
+
+	return manager.Run()
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Definitions used when BADGES_FILE is not set
+//
+//go:embed badges.json
+var defaultBadges []byte
+
+// BadgeConfig holds the badge definitions, read from a JSON file
+type BadgeConfig struct {
+	// JSON array of badge definitions, see badges.json
+	File string `env:"BADGES_FILE" default:""`
+
+	// Parsed from File by load
+	Definitions []BadgeDefinition
+}
+
+// BadgeDefinition is a badge awarded once a metric of the user reaches the threshold
+type BadgeDefinition struct {
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Metric      string  `json:"metric"`
+	Threshold   float64 `json:"threshold"`
+}
+
+// Metrics badges are awarded on
+const (
+	// Total km travelled
+	BadgeMetricDistance = "distance"
+	// Number of trips
+	BadgeMetricTrips = "trips"
+	// Total grams of CO2 saved
+	BadgeMetricCO2Saved = "co2_saved"
+	// Consecutive days with at least one trip, up to today
+	BadgeMetricStreakDays = "streak_days"
+)
+
+// Codes are stored in user_badges.badge_code
+const maxBadgeCodeLength = 64
+
+// MaxStreak returns the longest streak a badge asks for, the number of days
+// of history needed to award every streak badge
+func (c BadgeConfig) MaxStreak() int {
+	longest := 0
+	for _, badge := range c.Definitions {
+		if badge.Metric == BadgeMetricStreakDays {
+			longest = max(longest, int(badge.Threshold))
+		}
+	}
+	return longest
+}
+
+// Reads the definitions from File, or the embedded badges.json
+func (c *BadgeConfig) load() []string {
+	data := defaultBadges
+	if c.File != "" {
+		var err error
+		if data, err = os.ReadFile(c.File); err != nil {
+			return []string{fmt.Sprintf("BADGES_FILE cannot be read: %v", err)}
+		}
+	}
+
+	c.Definitions = nil
+	if err := json.Unmarshal(data, &c.Definitions); err != nil {
+		return []string{fmt.Sprintf("BADGES_FILE is not a valid JSON array of badges: %v", err)}
+	}
+	return nil
+}
+
+func (c BadgeConfig) validate() []string {
+	var problems []string
+	codes := make(map[string]bool, len(c.Definitions))
+
+	for i, badge := range c.Definitions {
+		switch {
+		case badge.Code == "" || len(badge.Code) > maxBadgeCodeLength:
+			problems = append(problems, fmt.Sprintf("BADGES_FILE badge %d must have a code of at most %d characters", i, maxBadgeCodeLength))
+		case codes[badge.Code]:
+			problems = append(problems, fmt.Sprintf("BADGES_FILE badge %q is defined twice", badge.Code))
+		}
+		codes[badge.Code] = true
+
+		if badge.Name == "" {
+			problems = append(problems, fmt.Sprintf("BADGES_FILE badge %q must have a name", badge.Code))
+		}
+		switch badge.Metric {
+		case BadgeMetricDistance, BadgeMetricTrips, BadgeMetricCO2Saved, BadgeMetricStreakDays:
+		default:
+			problems = append(problems, fmt.Sprintf("BADGES_FILE badge %q must have a metric among %s, %s, %s, %s",
+				badge.Code, BadgeMetricDistance, BadgeMetricTrips, BadgeMetricCO2Saved, BadgeMetricStreakDays))
+		}
+		if badge.Threshold <= 0 {
+			problems = append(problems, fmt.Sprintf("BADGES_FILE badge %q must have a positive threshold", badge.Code))
+		}
+	}
+
+	return problems
+}
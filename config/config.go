@@ -1,14 +1,97 @@
 package config
 
+import "time"
+
+// Config holds every setting the User Service reads at startup. Each leaf
+// field is bound to an environment variable through its `env` tag, falls back
+// to its `default` tag and can be overridden by the matching command line flag
+// (MYSQL_HOST -> -mysql-host).
 type Config struct {
-	// MySQL Setup
-	MySQLHost     string `mapstructure: "MYSQL_HOST"`
-	MySQLPort     string `mapstructure: "MYSQL_PORT"`
-	MySQLUser     string `mapstructure: "MYSQL_USER"`
-	MySQLPassword string `mapstructure: "MYSQL_ROOT_PASSWORD"`
-	MySQLDatabase string `mapstructure: "MYSQL_DATABASE"`
-
-	// Redis Setup
-	RedisHost string `mapstructure: "REDIS_HOST"`
-	RedisPort string `mapstructure: "REDIS_PORT"`
-}
\ No newline at end of file
+	Env         string `env:"APP_ENV" default:"development"`
+	Port        string `env:"PORT" default:"8082"`
+	FrontendURL string `env:"FRONTEND_URL" default:"http://localhost:5173"`
+
+	// Largest JSON body the REST routes read, in bytes
+	HTTPMaxBodyBytes int64 `env:"HTTP_MAX_BODY_BYTES" default:"1048576"`
+
+	// How long in-flight requests get to finish once a shutdown starts
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" default:"15s"`
+	// How long the service keeps serving while reporting NOT_SERVING before it stops
+	ShutdownDrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" default:"0s"`
+
+	GRPC         GRPCConfig
+	MySQL        MySQLConfig
+	Redis        RedisConfig
+	JWT          JWTConfig
+	Session      SessionConfig
+	SMTP         SMTPConfig
+	SMS          SMSConfig
+	Health       HealthConfig
+	Phone        PhoneConfig
+	Verification VerificationConfig
+	Emissions    EmissionsConfig
+	Points       PointsConfig
+	Tier         TierConfig
+	Events       EventsConfig
+	Leaderboard  LeaderboardConfig
+	Badges       BadgeConfig
+	Goals        GoalsConfig
+	Referral     ReferralConfig
+	Places       PlacesConfig
+	Safety       SafetyConfig
+	Preferences  PreferencesConfig
+}
+
+var AppConfig *Config
+
+// Reads the settings kept in files, which validate then checks
+func (c *Config) loadFiles() []string {
+	var problems []string
+
+	problems = append(problems, c.Phone.load()...)
+	problems = append(problems, c.Badges.load()...)
+
+	return problems
+}
+
+func (c *Config) validate() []string {
+	var problems []string
+
+	problems = append(problems, validatePort("PORT", c.Port)...)
+	if c.FrontendURL == "" {
+		problems = append(problems, "FRONTEND_URL is required")
+	}
+	if c.HTTPMaxBodyBytes <= 0 {
+		problems = append(problems, "HTTP_MAX_BODY_BYTES must be positive")
+	}
+	if c.ShutdownTimeout <= 0 {
+		problems = append(problems, "SHUTDOWN_TIMEOUT must be positive")
+	}
+	if c.ShutdownDrainDelay < 0 {
+		problems = append(problems, "SHUTDOWN_DRAIN_DELAY must not be negative")
+	}
+
+	problems = append(problems, c.GRPC.validate()...)
+	problems = append(problems, c.MySQL.validate()...)
+	problems = append(problems, c.Redis.validate()...)
+	problems = append(problems, c.JWT.validate()...)
+	problems = append(problems, c.Session.validate()...)
+	problems = append(problems, c.SMTP.validate()...)
+	problems = append(problems, c.SMS.validate()...)
+	problems = append(problems, c.Health.validate()...)
+	problems = append(problems, c.Phone.validate()...)
+	problems = append(problems, c.Verification.validate()...)
+	problems = append(problems, c.Emissions.validate()...)
+	problems = append(problems, c.Points.validate()...)
+	problems = append(problems, c.Tier.validate()...)
+	problems = append(problems, c.Events.validate()...)
+	problems = append(problems, c.Leaderboard.validate()...)
+	problems = append(problems, c.Badges.validate()...)
+	problems = append(problems, c.Goals.validate()...)
+	problems = append(problems, c.Referral.validate()...)
+	problems = append(problems, c.Places.validate()...)
+	problems = append(problems, c.Safety.validate()...)
+	problems = append(problems, c.Preferences.validate()...)
+
+	return problems
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/distancekind"
+)
+
+// EmissionsConfig is the model turning distance travelled into CO2 avoided,
+// compared with the same trip in a private petrol car
+type EmissionsConfig struct {
+	// Grams of CO2 saved per km, by vehicle type
+	EVSavedPerKm         float64 `env:"EMISSIONS_EV_SAVED_G_PER_KM" default:"120"`
+	HybridSavedPerKm     float64 `env:"EMISSIONS_HYBRID_SAVED_G_PER_KM" default:"60"`
+	SharedRideSavedPerKm float64 `env:"EMISSIONS_SHARED_RIDE_SAVED_G_PER_KM" default:"85"`
+	// Used for trips recorded without a vehicle type
+	DefaultVehicleType string `env:"EMISSIONS_DEFAULT_VEHICLE_TYPE" default:"ev"`
+	// Used for admin adjustments, which are not travelled in any vehicle
+	AdjustmentSavedPerKm float64 `env:"EMISSIONS_ADJUSTMENT_SAVED_G_PER_KM" default:"0"`
+
+	// Grams of CO2 one tree absorbs in a year
+	TreeAbsorptionPerYear float64 `env:"EMISSIONS_TREE_G_PER_YEAR" default:"21000"`
+	// Grams of CO2 emitted by burning one litre of petrol
+	FuelEmissionsPerLitre float64 `env:"EMISSIONS_FUEL_G_PER_LITRE" default:"2310"`
+}
+
+// Vehicle types understood by SavedPerKm
+const (
+	VehicleTypeEV         = "ev"
+	VehicleTypeHybrid     = "hybrid"
+	VehicleTypeSharedRide = "shared_ride"
+)
+
+// SavedPerKm returns the grams of CO2 saved per km of a distance entry of the
+// given kind: the adjustment rate for adjustments, the rate of the vehicle
+// type for trips, using the default vehicle type for unknown or empty ones
+func (c EmissionsConfig) SavedPerKm(kind, vehicleType string) float64 {
+	if kind == distancekind.Adjustment {
+		return c.AdjustmentSavedPerKm
+	}
+
+	switch vehicleType {
+	case VehicleTypeEV:
+		return c.EVSavedPerKm
+	case VehicleTypeHybrid:
+		return c.HybridSavedPerKm
+	case VehicleTypeSharedRide:
+		return c.SharedRideSavedPerKm
+	}
+	if vehicleType != c.DefaultVehicleType {
+		return c.SavedPerKm(kind, c.DefaultVehicleType)
+	}
+	return 0
+}
+
+func (c EmissionsConfig) validate() []string {
+	var problems []string
+
+	for name, value := range map[string]float64{
+		"EMISSIONS_EV_SAVED_G_PER_KM":          c.EVSavedPerKm,
+		"EMISSIONS_HYBRID_SAVED_G_PER_KM":      c.HybridSavedPerKm,
+		"EMISSIONS_SHARED_RIDE_SAVED_G_PER_KM": c.SharedRideSavedPerKm,
+		"EMISSIONS_ADJUSTMENT_SAVED_G_PER_KM":  c.AdjustmentSavedPerKm,
+	} {
+		if value < 0 {
+			problems = append(problems, fmt.Sprintf("%s must not be negative", name))
+		}
+	}
+
+	switch c.DefaultVehicleType {
+	case VehicleTypeEV, VehicleTypeHybrid, VehicleTypeSharedRide:
+	default:
+		problems = append(problems, fmt.Sprintf("EMISSIONS_DEFAULT_VEHICLE_TYPE must be one of %s, %s, %s", VehicleTypeEV, VehicleTypeHybrid, VehicleTypeSharedRide))
+	}
+
+	if c.TreeAbsorptionPerYear <= 0 {
+		problems = append(problems, "EMISSIONS_TREE_G_PER_YEAR must be positive")
+	}
+	if c.FuelEmissionsPerLitre <= 0 {
+		problems = append(problems, "EMISSIONS_FUEL_G_PER_LITRE must be positive")
+	}
+
+	return problems
+}
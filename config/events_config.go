@@ -0,0 +1,21 @@
+package config
+
+// EventsConfig is the Redis stream domain events are published to
+type EventsConfig struct {
+	Stream string `env:"EVENTS_STREAM" default:"user_service:events"`
+	// Older events are trimmed once the stream holds about this many
+	StreamMaxLen int64 `env:"EVENTS_STREAM_MAX_LEN" default:"100000"`
+}
+
+func (c EventsConfig) validate() []string {
+	var problems []string
+
+	if c.Stream == "" {
+		problems = append(problems, "EVENTS_STREAM is required")
+	}
+	if c.StreamMaxLen < 1 {
+		problems = append(problems, "EVENTS_STREAM_MAX_LEN must be at least 1")
+	}
+
+	return problems
+}
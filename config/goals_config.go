@@ -0,0 +1,39 @@
+package config
+
+import "time"
+
+// GoalsConfig holds the settings of monthly goals, streaks and streak reminders
+type GoalsConfig struct {
+	// How far back trips are read to count streaks, the longest streak counted
+	StreakLookback time.Duration `env:"GOALS_STREAK_LOOKBACK" default:"8760h"`
+
+	// How often users whose streak is at risk are looked for
+	ReminderInterval time.Duration `env:"GOALS_REMINDER_INTERVAL" default:"1h"`
+	// Local hour of the user's day from which they are reminded
+	ReminderHour int `env:"GOALS_REMINDER_HOUR" default:"18"`
+	// Shortest daily streak worth a reminder
+	ReminderMinStreak int `env:"GOALS_REMINDER_MIN_STREAK" default:"2"`
+	ReminderBatchSize int `env:"GOALS_REMINDER_BATCH_SIZE" default:"500"`
+}
+
+func (c GoalsConfig) validate() []string {
+	var problems []string
+
+	if c.StreakLookback < 7*24*time.Hour {
+		problems = append(problems, "GOALS_STREAK_LOOKBACK must be at least 168h")
+	}
+	if c.ReminderInterval <= 0 {
+		problems = append(problems, "GOALS_REMINDER_INTERVAL must be positive")
+	}
+	if c.ReminderHour < 0 || c.ReminderHour > 23 {
+		problems = append(problems, "GOALS_REMINDER_HOUR must be between 0 and 23")
+	}
+	if c.ReminderMinStreak < 1 {
+		problems = append(problems, "GOALS_REMINDER_MIN_STREAK must be at least 1")
+	}
+	if c.ReminderBatchSize < 1 {
+		problems = append(problems, "GOALS_REMINDER_BATCH_SIZE must be at least 1")
+	}
+
+	return problems
+}
@@ -1 +1,19 @@
-package config
\ No newline at end of file
+package config
+
+type GRPCConfig struct {
+	Port string `env:"GRPC_PORT" default:"5002"`
+	// Shared secret internal services (trip service, API gateway) send in the
+	// "x-service-token" metadata to call RPCs on behalf of any user. Internal
+	// callers are refused when it is empty.
+	ServiceToken string `env:"GRPC_SERVICE_TOKEN"`
+}
+
+func (c GRPCConfig) validate() []string {
+	problems := validatePort("GRPC_PORT", c.Port)
+
+	if c.ServiceToken != "" && len(c.ServiceToken) < 32 {
+		problems = append(problems, "GRPC_SERVICE_TOKEN must be at least 32 characters")
+	}
+
+	return problems
+}
@@ -0,0 +1,23 @@
+package config
+
+import "time"
+
+type HealthConfig struct {
+	CheckInterval time.Duration `env:"HEALTH_CHECK_INTERVAL" default:"10s"`
+	CheckTimeout  time.Duration `env:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+	// SMTP reachability is optional since mail failures do not stop the service
+	CheckSMTP bool `env:"HEALTH_CHECK_SMTP" default:"false"`
+}
+
+func (c HealthConfig) validate() []string {
+	var problems []string
+
+	if c.CheckInterval <= 0 {
+		problems = append(problems, "HEALTH_CHECK_INTERVAL must be positive")
+	}
+	if c.CheckTimeout <= 0 || c.CheckTimeout > c.CheckInterval {
+		problems = append(problems, "HEALTH_CHECK_TIMEOUT must be positive and not longer than HEALTH_CHECK_INTERVAL")
+	}
+
+	return problems
+}
@@ -0,0 +1,15 @@
+package config
+
+type JWTConfig struct {
+	Secret string `env:"JWT_SECRET"`
+}
+
+func (c JWTConfig) validate() []string {
+	var problems []string
+
+	if c.Secret == "" {
+		problems = append(problems, "JWT_SECRET is required")
+	}
+
+	return problems
+}
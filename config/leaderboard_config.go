@@ -0,0 +1,19 @@
+package config
+
+import "time"
+
+type LeaderboardConfig struct {
+	// How often the service checks that the leaderboards still exist in
+	// Redis, rebuilding them from the distance ledger when they do not
+	CheckInterval time.Duration `env:"LEADERBOARD_CHECK_INTERVAL" default:"5m"`
+}
+
+func (c LeaderboardConfig) validate() []string {
+	var problems []string
+
+	if c.CheckInterval <= 0 {
+		problems = append(problems, "LEADERBOARD_CHECK_INTERVAL must be positive")
+	}
+
+	return problems
+}
@@ -0,0 +1,181 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// ValidationError lists every problem found while loading the configuration
+// so they can all be fixed in one go instead of one restart at a time.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// LoadConfig builds the configuration from, in increasing order of
+// precedence: the `default` tags, the optional env file (-env-file, app.env
+// by default), the process environment and the command line flags.
+func LoadConfig(args []string) (*Config, error) {
+	cfg := &Config{}
+	fields := collectFields(reflect.ValueOf(cfg).Elem())
+
+	fs := flag.NewFlagSet("user_service", flag.ContinueOnError)
+	envFile := fs.String("env-file", "app.env", "optional env file to load settings from")
+	flagValues := make(map[string]*string, len(fields))
+	for _, f := range fields {
+		flagValues[f.key] = fs.String(flagName(f.key), "", fmt.Sprintf("overrides %s", f.key))
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(fields))
+	for _, f := range fields {
+		values[f.key] = f.def
+	}
+
+	fileValues, err := godotenv.Read(*envFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read env file %s: %w", *envFile, err)
+	}
+	for key, value := range fileValues {
+		if _, ok := values[key]; ok {
+			values[key] = value
+		}
+	}
+
+	for _, f := range fields {
+		if value, ok := os.LookupEnv(f.key); ok {
+			values[f.key] = value
+		}
+	}
+
+	fs.Visit(func(fl *flag.Flag) {
+		for _, f := range fields {
+			if flagName(f.key) == fl.Name {
+				values[f.key] = *flagValues[f.key]
+			}
+		}
+	})
+
+	var problems []string
+	for _, f := range fields {
+		if err := setField(f.value, values[f.key]); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", f.key, err))
+		}
+	}
+	problems = append(problems, cfg.loadFiles()...)
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	AppConfig = cfg
+	return cfg, nil
+}
+
+type configField struct {
+	key   string
+	def   string
+	value reflect.Value
+}
+
+// Walks the config struct and returns every field bound to an env key
+func collectFields(v reflect.Value) []configField {
+	var fields []configField
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+
+		if key, ok := field.Tag.Lookup("env"); ok {
+			fields = append(fields, configField{key: key, def: field.Tag.Get("default"), value: value})
+			continue
+		}
+		if field.Type.Kind() == reflect.Struct {
+			fields = append(fields, collectFields(value)...)
+		}
+	}
+
+	return fields
+}
+
+func setField(v reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		if raw == "" {
+			v.SetInt(0)
+			return nil
+		}
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid duration", raw)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int64:
+		if raw == "" {
+			v.SetInt(0)
+			return nil
+		}
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid integer", raw)
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		if raw == "" {
+			v.SetFloat(0)
+			return nil
+		}
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid number", raw)
+		}
+		v.SetFloat(n)
+	case reflect.Bool:
+		if raw == "" {
+			v.SetBool(false)
+			return nil
+		}
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid boolean", raw)
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+
+	return nil
+}
+
+func flagName(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "_", "-")
+}
+
+func validatePort(key, port string) []string {
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return []string{fmt.Sprintf("%s must be a port number between 1 and 65535", key)}
+	}
+	return nil
+}
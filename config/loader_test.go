@@ -0,0 +1,47 @@
+package config
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigReportsEveryProblem(t *testing.T) {
+	t.Setenv("JWT_SECRET", "")
+
+	_, err := LoadConfig([]string{
+		"-env-file", t.TempDir() + "/missing.env",
+		"-shutdown-timeout", "soon",
+		"-badges-file", t.TempDir() + "/missing.json",
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("LoadConfig() error = %v, want a ValidationError", err)
+	}
+
+	// One unparsable value, one unreadable file and one failed check
+	for _, want := range []string{"SHUTDOWN_TIMEOUT: ", "BADGES_FILE cannot be read", "JWT_SECRET is required"} {
+		if !slices.ContainsFunc(validationErr.Problems, func(problem string) bool { return strings.HasPrefix(problem, want) }) {
+			t.Errorf("problems %q have none starting with %q", validationErr.Problems, want)
+		}
+	}
+}
+
+func TestLoadFiles(t *testing.T) {
+	cfg := &Config{Phone: PhoneConfig{DefaultRegion: "SG"}}
+	if problems := cfg.loadFiles(); len(problems) > 0 {
+		t.Fatalf("loadFiles() = %q, want no problems", problems)
+	}
+	if len(cfg.Badges.Definitions) == 0 || len(cfg.Phone.Regions) == 0 {
+		t.Errorf("loadFiles() read %d badges and %d regions, want the embedded defaults",
+			len(cfg.Badges.Definitions), len(cfg.Phone.Regions))
+	}
+	if problems := cfg.Phone.validate(); len(problems) > 0 {
+		t.Errorf("Phone.validate() = %q for the embedded regions, want no problems", problems)
+	}
+	if problems := cfg.Badges.validate(); len(problems) > 0 {
+		t.Errorf("Badges.validate() = %q for the embedded badges, want no problems", problems)
+	}
+}
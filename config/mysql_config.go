@@ -3,7 +3,6 @@ package config
 import (
 	"fmt"
 	"log"
-	"os"
 	"time"
 
 	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
@@ -11,16 +10,54 @@ import (
 	"gorm.io/gorm"
 )
 
-var DB *gorm.DB;
+type MySQLConfig struct {
+	Host            string        `env:"MYSQL_HOST" default:"localhost"`
+	Port            string        `env:"MYSQL_PORT" default:"3306"`
+	User            string        `env:"MYSQL_USER"`
+	Password        string        `env:"MYSQL_PASSWORD"`
+	Database        string        `env:"MYSQL_DATABASE"`
+	MaxOpenConns    int           `env:"MYSQL_MAX_OPEN_CONNS" default:"10"`
+	MaxIdleConns    int           `env:"MYSQL_MAX_IDLE_CONNS" default:"10"`
+	ConnMaxLifetime time.Duration `env:"MYSQL_CONN_MAX_LIFETIME" default:"3m"`
+}
+
+func (c MySQLConfig) validate() []string {
+	var problems []string
+
+	if c.Host == "" {
+		problems = append(problems, "MYSQL_HOST is required")
+	}
+	problems = append(problems, validatePort("MYSQL_PORT", c.Port)...)
+	if c.User == "" {
+		problems = append(problems, "MYSQL_USER is required")
+	}
+	if c.Database == "" {
+		problems = append(problems, "MYSQL_DATABASE is required")
+	}
+	if c.MaxOpenConns < 1 {
+		problems = append(problems, "MYSQL_MAX_OPEN_CONNS must be at least 1")
+	}
+	if c.MaxIdleConns < 0 || c.MaxIdleConns > c.MaxOpenConns {
+		problems = append(problems, "MYSQL_MAX_IDLE_CONNS must be between 0 and MYSQL_MAX_OPEN_CONNS")
+	}
+	if c.ConnMaxLifetime <= 0 {
+		problems = append(problems, "MYSQL_CONN_MAX_LIFETIME must be positive")
+	}
+
+	return problems
+}
+
+var DB *gorm.DB
 
-func ConnectToMySQL() error {
-	// Building the Data Source Name (DSN) connection string
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		os.Getenv("MYSQL_USER"),
-		os.Getenv("MYSQL_PASSWORD"),
-		os.Getenv("MYSQL_HOST"),
-		os.Getenv("MYSQL_PORT"),
-		os.Getenv("MYSQL_DATABASE"),
+func ConnectToMySQL(cfg MySQLConfig) error {
+	// Building the Data Source Name (DSN) connection string. Times are stored
+	// in UTC, so they never depend on the zone of the server or its DST changes.
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
+		cfg.User,
+		cfg.Password,
+		cfg.Host,
+		cfg.Port,
+		cfg.Database,
 	)
 
 	// Opening a GORM connection with MySQL
@@ -38,13 +75,26 @@ func ConnectToMySQL() error {
 	}
 
 	// Configuring connection pool settings
-	sqlDB.SetConnMaxLifetime(time.Minute * 3)
-	sqlDB.SetMaxOpenConns(10)
-	sqlDB.SetMaxIdleConns(10)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
 
 	DB = db
-	DB.AutoMigrate(&model.User{})
+	DB.AutoMigrate(&model.User{}, &model.DistanceEntry{}, &model.PointsTransaction{}, &model.PointsBalance{}, &model.Membership{}, &model.TierChange{}, &model.UserBadge{}, &model.Goal{}, &model.Referral{}, &model.SavedPlace{}, &model.EmergencyContact{}, &model.Preferences{})
 	log.Println("Connected to MySQL!")
-	
+
 	return nil
 }
+
+// Closes the connection pool opened by ConnectToMySQL
+func CloseMySQL() error {
+	if DB == nil {
+		return nil
+	}
+
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
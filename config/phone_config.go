@@ -0,0 +1,98 @@
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/phone"
+)
+
+// Regions used when PHONE_REGIONS_FILE is not set
+//
+//go:embed phone_regions.json
+var defaultPhoneRegions []byte
+
+type PhoneConfig struct {
+	// Region national numbers without a country code are assumed to be from
+	DefaultRegion string `env:"PHONE_DEFAULT_REGION" default:"SG"`
+	// JSON object of the national formats by region, see phone_regions.json
+	RegionsFile string `env:"PHONE_REGIONS_FILE" default:""`
+
+	// Parsed from RegionsFile by load
+	Regions map[string]phone.Region
+}
+
+// Returns the E.164 form of a phone number, reading national numbers in the default region
+func (c PhoneConfig) Normalize(raw string) (string, error) {
+	return phone.Normalize(raw, c.DefaultRegion, c.Regions)
+}
+
+// Reads the regions from RegionsFile, or the embedded phone_regions.json
+func (c *PhoneConfig) load() []string {
+	data := defaultPhoneRegions
+	if c.RegionsFile != "" {
+		var err error
+		if data, err = os.ReadFile(c.RegionsFile); err != nil {
+			return []string{fmt.Sprintf("PHONE_REGIONS_FILE cannot be read: %v", err)}
+		}
+	}
+
+	c.Regions = nil
+	if err := json.Unmarshal(data, &c.Regions); err != nil {
+		return []string{fmt.Sprintf("PHONE_REGIONS_FILE is not a valid JSON object of regions: %v", err)}
+	}
+	return nil
+}
+
+func (c PhoneConfig) validate() []string {
+	codes := make([]string, 0, len(c.Regions))
+	for code := range c.Regions {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	var problems []string
+	for i, code := range codes {
+		region := c.Regions[code]
+		if !isDigits(region.CallingCode) || len(region.CallingCode) > 3 {
+			problems = append(problems, fmt.Sprintf("PHONE_REGIONS_FILE region %q must have a calling code of 1 to 3 digits", code))
+		}
+		if region.TrunkPrefix != "" && !isDigits(region.TrunkPrefix) {
+			problems = append(problems, fmt.Sprintf("PHONE_REGIONS_FILE region %q must have a numeric trunk prefix", code))
+		}
+		if region.MinLength <= 0 || region.MaxLength < region.MinLength {
+			problems = append(problems, fmt.Sprintf("PHONE_REGIONS_FILE region %q must have a positive min_length of at most max_length", code))
+		}
+
+		// International numbers are matched to regions by calling code, which
+		// several regions may share but must not start another one
+		for _, other := range codes[:i] {
+			otherRegion := c.Regions[other]
+			if region.CallingCode != "" && otherRegion.CallingCode != "" && region.CallingCode != otherRegion.CallingCode &&
+				(strings.HasPrefix(region.CallingCode, otherRegion.CallingCode) || strings.HasPrefix(otherRegion.CallingCode, region.CallingCode)) {
+				problems = append(problems, fmt.Sprintf("PHONE_REGIONS_FILE regions %q and %q have overlapping calling codes", other, code))
+			}
+		}
+	}
+
+	if _, ok := c.Regions[c.DefaultRegion]; !ok {
+		problems = append(problems, fmt.Sprintf("PHONE_DEFAULT_REGION %q is not a region of PHONE_REGIONS_FILE", c.DefaultRegion))
+	}
+	return problems
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/phone"
+)
+
+func TestPhoneConfigCallingCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		regions map[string]phone.Region
+		overlap bool
+	}{
+		{
+			name: "distinct calling codes",
+			regions: map[string]phone.Region{
+				"SG": {CallingCode: "65", MinLength: 8, MaxLength: 8},
+				"US": {CallingCode: "1", MinLength: 10, MaxLength: 10},
+			},
+		},
+		{
+			name: "shared calling code",
+			regions: map[string]phone.Region{
+				"SG": {CallingCode: "65", MinLength: 8, MaxLength: 8},
+				"US": {CallingCode: "1", MinLength: 10, MaxLength: 10},
+				"CA": {CallingCode: "1", MinLength: 10, MaxLength: 10},
+			},
+		},
+		{
+			name: "calling code starting another",
+			regions: map[string]phone.Region{
+				"SG": {CallingCode: "65", MinLength: 8, MaxLength: 8},
+				"XX": {CallingCode: "6", MinLength: 8, MaxLength: 8},
+			},
+			overlap: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems := PhoneConfig{DefaultRegion: "SG", Regions: test.regions}.validate()
+			overlap := slices.ContainsFunc(problems, func(problem string) bool { return strings.Contains(problem, "overlapping calling codes") })
+			if overlap != test.overlap {
+				t.Errorf("validate() = %q, want overlapping calling codes reported: %v", problems, test.overlap)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package config
+
+// PlacesConfig bounds the places a user can save
+type PlacesConfig struct {
+	MaxPerUser int64 `env:"SAVED_PLACES_MAX_PER_USER" default:"20"`
+}
+
+func (c PlacesConfig) validate() []string {
+	var problems []string
+
+	if c.MaxPerUser < 1 {
+		problems = append(problems, "SAVED_PLACES_MAX_PER_USER must be at least 1")
+	}
+
+	return problems
+}
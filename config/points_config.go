@@ -0,0 +1,69 @@
+package config
+
+import (
+	"math"
+	"time"
+)
+
+// PointsConfig holds the rules for earning and expiring green points
+type PointsConfig struct {
+	PerKm float64 `env:"POINTS_PER_KM" default:"10"`
+	// Applied to PerKm by vehicle type, 1 for trips without one
+	EVMultiplier         float64 `env:"POINTS_EV_MULTIPLIER" default:"1.5"`
+	HybridMultiplier     float64 `env:"POINTS_HYBRID_MULTIPLIER" default:"1"`
+	SharedRideMultiplier float64 `env:"POINTS_SHARED_RIDE_MULTIPLIER" default:"1.2"`
+	MaxPerTrip           int64   `env:"POINTS_MAX_PER_TRIP" default:"5000"`
+
+	// How long points stay valid after they are earned or granted
+	ExpiresAfter time.Duration `env:"POINTS_EXPIRES_AFTER" default:"8760h"`
+	// How often expired points are looked for, and how many grants are expired per pass
+	ExpiryInterval  time.Duration `env:"POINTS_EXPIRY_INTERVAL" default:"1h"`
+	ExpiryBatchSize int           `env:"POINTS_EXPIRY_BATCH_SIZE" default:"500"`
+}
+
+// ForTrip returns the points earned by a trip, rounded down
+func (c PointsConfig) ForTrip(distance float64, vehicleType string) int64 {
+	multiplier := 1.0
+	switch vehicleType {
+	case VehicleTypeEV:
+		multiplier = c.EVMultiplier
+	case VehicleTypeHybrid:
+		multiplier = c.HybridMultiplier
+	case VehicleTypeSharedRide:
+		multiplier = c.SharedRideMultiplier
+	}
+
+	points := int64(math.Floor(distance * c.PerKm * multiplier))
+	if points > c.MaxPerTrip {
+		return c.MaxPerTrip
+	}
+	if points < 0 {
+		return 0
+	}
+	return points
+}
+
+func (c PointsConfig) validate() []string {
+	var problems []string
+
+	if c.PerKm < 0 {
+		problems = append(problems, "POINTS_PER_KM must not be negative")
+	}
+	if c.EVMultiplier < 0 || c.HybridMultiplier < 0 || c.SharedRideMultiplier < 0 {
+		problems = append(problems, "POINTS_*_MULTIPLIER must not be negative")
+	}
+	if c.MaxPerTrip < 0 {
+		problems = append(problems, "POINTS_MAX_PER_TRIP must not be negative")
+	}
+	if c.ExpiresAfter <= 0 {
+		problems = append(problems, "POINTS_EXPIRES_AFTER must be positive")
+	}
+	if c.ExpiryInterval <= 0 {
+		problems = append(problems, "POINTS_EXPIRY_INTERVAL must be positive")
+	}
+	if c.ExpiryBatchSize < 1 {
+		problems = append(problems, "POINTS_EXPIRY_BATCH_SIZE must be at least 1")
+	}
+
+	return problems
+}
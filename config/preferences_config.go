@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PreferencesConfig holds the preferences of users who have not set them
+type PreferencesConfig struct {
+	DefaultLanguage     string `env:"PREFERENCES_DEFAULT_LANGUAGE" default:"en"`
+	DefaultDistanceUnit string `env:"PREFERENCES_DEFAULT_DISTANCE_UNIT" default:"km"`
+}
+
+// Languages emails are written in
+const (
+	LanguageEnglish    = "en"
+	LanguageVietnamese = "vi"
+)
+
+var Languages = []string{LanguageEnglish, LanguageVietnamese}
+
+// Units distances are shown in
+const (
+	DistanceUnitKm    = "km"
+	DistanceUnitMiles = "mi"
+)
+
+func (c PreferencesConfig) validate() []string {
+	var problems []string
+
+	if !slices.Contains(Languages, c.DefaultLanguage) {
+		problems = append(problems, fmt.Sprintf("PREFERENCES_DEFAULT_LANGUAGE must be one of %s", strings.Join(Languages, ", ")))
+	}
+	if c.DefaultDistanceUnit != DistanceUnitKm && c.DefaultDistanceUnit != DistanceUnitMiles {
+		problems = append(problems, "PREFERENCES_DEFAULT_DISTANCE_UNIT must be km or mi")
+	}
+
+	return problems
+}
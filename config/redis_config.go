@@ -3,27 +3,46 @@ package config
 import (
 	"context"
 	"log"
-	"os"
-	"strconv"
 
 	"github.com/redis/go-redis/v9"
 )
 
-var Redis *redis.Client;
+type RedisConfig struct {
+	Host     string `env:"REDIS_HOST" default:"localhost"`
+	Port     string `env:"REDIS_PORT" default:"6379"`
+	Password string `env:"REDIS_PASSWORD"`
+	DB       int    `env:"REDIS_DB" default:"0"`
+	PoolSize int    `env:"REDIS_POOL_SIZE" default:"10"`
+}
 
-func ConnectToRedis() error {
-	db, err := strconv.Atoi(os.Getenv("REDIS_DB"))
-	if err != nil {
-		return err
+func (c RedisConfig) validate() []string {
+	var problems []string
+
+	if c.Host == "" {
+		problems = append(problems, "REDIS_HOST is required")
+	}
+	problems = append(problems, validatePort("REDIS_PORT", c.Port)...)
+	if c.DB < 0 {
+		problems = append(problems, "REDIS_DB must not be negative")
 	}
+	if c.PoolSize < 1 {
+		problems = append(problems, "REDIS_POOL_SIZE must be at least 1")
+	}
+
+	return problems
+}
 
+var Redis *redis.Client
+
+func ConnectToRedis(cfg RedisConfig) error {
 	rdb := redis.NewClient(&redis.Options{
-		Addr: os.Getenv("REDIS_HOST") + ":" + os.Getenv("REDIS_PORT"),
-		Password: os.Getenv("REDIS_PASSWORD"),
-		DB:   db,
+		Addr:     cfg.Host + ":" + cfg.Port,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+		PoolSize: cfg.PoolSize,
 	})
 
-	_, err = rdb.Ping(context.Background()).Result()
+	_, err := rdb.Ping(context.Background()).Result()
 
 	if err != nil {
 		return err
@@ -33,5 +52,12 @@ func ConnectToRedis() error {
 	log.Println("Connected to Redis!")
 
 	return nil
+}
 
-}
\ No newline at end of file
+// Closes the client opened by ConnectToRedis
+func CloseRedis() error {
+	if Redis == nil {
+		return nil
+	}
+	return Redis.Close()
+}
@@ -0,0 +1,26 @@
+package config
+
+// ReferralConfig holds the rewards of the referral program
+type ReferralConfig struct {
+	// Points credited to each side once the referred user completes their first trip
+	ReferrerPoints int64 `env:"REFERRAL_REFERRER_POINTS" default:"500"`
+	RefereePoints  int64 `env:"REFERRAL_REFEREE_POINTS" default:"200"`
+	// Most referrals a user can be rewarded for, pending ones included
+	MaxPerReferrer int64 `env:"REFERRAL_MAX_PER_REFERRER" default:"50"`
+}
+
+func (c ReferralConfig) validate() []string {
+	var problems []string
+
+	if c.ReferrerPoints < 0 {
+		problems = append(problems, "REFERRAL_REFERRER_POINTS must not be negative")
+	}
+	if c.RefereePoints < 0 {
+		problems = append(problems, "REFERRAL_REFEREE_POINTS must not be negative")
+	}
+	if c.MaxPerReferrer < 1 {
+		problems = append(problems, "REFERRAL_MAX_PER_REFERRER must be at least 1")
+	}
+
+	return problems
+}
@@ -0,0 +1,16 @@
+package config
+
+// SafetyConfig bounds the emergency contacts of a user
+type SafetyConfig struct {
+	MaxContacts int64 `env:"SAFETY_MAX_EMERGENCY_CONTACTS" default:"5"`
+}
+
+func (c SafetyConfig) validate() []string {
+	var problems []string
+
+	if c.MaxContacts < 1 {
+		problems = append(problems, "SAFETY_MAX_EMERGENCY_CONTACTS must be at least 1")
+	}
+
+	return problems
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// SessionPolicy describes how long the tokens of one kind of client live.
+// The refresh token stays valid while it is used at least once per
+// IdleTimeout, but never longer than MaxAge after the login.
+type SessionPolicy struct {
+	AccessTokenTTL time.Duration
+	IdleTimeout    time.Duration
+	MaxAge         time.Duration
+}
+
+type SessionConfig struct {
+	WebAccessTokenTTL        time.Duration `env:"SESSION_WEB_ACCESS_TOKEN_TTL" default:"15m"`
+	WebIdleTimeout           time.Duration `env:"SESSION_WEB_IDLE_TIMEOUT" default:"24h"`
+	WebMaxAge                time.Duration `env:"SESSION_WEB_MAX_AGE" default:"168h"`
+	WebRememberMeIdleTimeout time.Duration `env:"SESSION_WEB_REMEMBER_ME_IDLE_TIMEOUT" default:"168h"`
+	WebRememberMeMaxAge      time.Duration `env:"SESSION_WEB_REMEMBER_ME_MAX_AGE" default:"720h"`
+
+	MobileAccessTokenTTL        time.Duration `env:"SESSION_MOBILE_ACCESS_TOKEN_TTL" default:"15m"`
+	MobileIdleTimeout           time.Duration `env:"SESSION_MOBILE_IDLE_TIMEOUT" default:"168h"`
+	MobileMaxAge                time.Duration `env:"SESSION_MOBILE_MAX_AGE" default:"720h"`
+	MobileRememberMeIdleTimeout time.Duration `env:"SESSION_MOBILE_REMEMBER_ME_IDLE_TIMEOUT" default:"720h"`
+	MobileRememberMeMaxAge      time.Duration `env:"SESSION_MOBILE_REMEMBER_ME_MAX_AGE" default:"2160h"`
+
+	DriverAccessTokenTTL        time.Duration `env:"SESSION_DRIVER_ACCESS_TOKEN_TTL" default:"30m"`
+	DriverIdleTimeout           time.Duration `env:"SESSION_DRIVER_IDLE_TIMEOUT" default:"12h"`
+	DriverMaxAge                time.Duration `env:"SESSION_DRIVER_MAX_AGE" default:"24h"`
+	DriverRememberMeIdleTimeout time.Duration `env:"SESSION_DRIVER_REMEMBER_ME_IDLE_TIMEOUT" default:"24h"`
+	DriverRememberMeMaxAge      time.Duration `env:"SESSION_DRIVER_REMEMBER_ME_MAX_AGE" default:"168h"`
+}
+
+// Client types understood by Policy. Unknown client types get the web policy.
+const (
+	ClientTypeWeb       = "web"
+	ClientTypeMobileApp = "mobile_app"
+	ClientTypeDriverApp = "driver_app"
+)
+
+// Policy returns the session lifetimes for a client type, using the longer
+// remember-me lifetimes when the user asked to stay signed in.
+func (c SessionConfig) Policy(clientType string, rememberMe bool) SessionPolicy {
+	switch clientType {
+	case ClientTypeMobileApp:
+		if rememberMe {
+			return SessionPolicy{c.MobileAccessTokenTTL, c.MobileRememberMeIdleTimeout, c.MobileRememberMeMaxAge}
+		}
+		return SessionPolicy{c.MobileAccessTokenTTL, c.MobileIdleTimeout, c.MobileMaxAge}
+	case ClientTypeDriverApp:
+		if rememberMe {
+			return SessionPolicy{c.DriverAccessTokenTTL, c.DriverRememberMeIdleTimeout, c.DriverRememberMeMaxAge}
+		}
+		return SessionPolicy{c.DriverAccessTokenTTL, c.DriverIdleTimeout, c.DriverMaxAge}
+	default:
+		if rememberMe {
+			return SessionPolicy{c.WebAccessTokenTTL, c.WebRememberMeIdleTimeout, c.WebRememberMeMaxAge}
+		}
+		return SessionPolicy{c.WebAccessTokenTTL, c.WebIdleTimeout, c.WebMaxAge}
+	}
+}
+
+func (c SessionConfig) validate() []string {
+	var problems []string
+
+	for _, clientType := range []string{ClientTypeWeb, ClientTypeMobileApp, ClientTypeDriverApp} {
+		for _, rememberMe := range []bool{false, true} {
+			policy := c.Policy(clientType, rememberMe)
+			name := clientType
+			if rememberMe {
+				name += " remember-me"
+			}
+
+			if policy.AccessTokenTTL <= 0 {
+				problems = append(problems, fmt.Sprintf("%s session access token TTL must be positive", name))
+			}
+			if policy.IdleTimeout <= policy.AccessTokenTTL {
+				problems = append(problems, fmt.Sprintf("%s session idle timeout must be longer than its access token TTL", name))
+			}
+			if policy.MaxAge < policy.IdleTimeout {
+				problems = append(problems, fmt.Sprintf("%s session max age must not be shorter than its idle timeout", name))
+			}
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,25 @@
+package config
+
+import "time"
+
+// SMS are posted as JSON to an HTTP gateway. Without a gateway URL they are
+// only logged, which is enough for local development.
+type SMSConfig struct {
+	GatewayURL string        `env:"SMS_GATEWAY_URL"`
+	APIKey     string        `env:"SMS_API_KEY"`
+	Sender     string        `env:"SMS_SENDER" default:"EcoTaxi"`
+	Timeout    time.Duration `env:"SMS_TIMEOUT" default:"10s"`
+}
+
+func (c SMSConfig) validate() []string {
+	var problems []string
+
+	if c.GatewayURL != "" && c.APIKey == "" {
+		problems = append(problems, "SMS_API_KEY is required when SMS_GATEWAY_URL is set")
+	}
+	if c.Timeout <= 0 {
+		problems = append(problems, "SMS_TIMEOUT must be positive")
+	}
+
+	return problems
+}
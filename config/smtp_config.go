@@ -0,0 +1,25 @@
+package config
+
+type SMTPConfig struct {
+	Host     string `env:"SMTP_HOST"`
+	Port     int    `env:"SMTP_PORT" default:"587"`
+	User     string `env:"SMTP_USER"`
+	Password string `env:"SMTP_PASSWORD"`
+	Sender   string `env:"EMAIL_SENDER"`
+}
+
+func (c SMTPConfig) validate() []string {
+	var problems []string
+
+	if c.Host == "" {
+		problems = append(problems, "SMTP_HOST is required")
+	}
+	if c.Port < 1 || c.Port > 65535 {
+		problems = append(problems, "SMTP_PORT must be between 1 and 65535")
+	}
+	if c.Sender == "" {
+		problems = append(problems, "EMAIL_SENDER is required")
+	}
+
+	return problems
+}
@@ -0,0 +1,87 @@
+package config
+
+import "time"
+
+// TierConfig holds the thresholds of the membership tiers, met by both the
+// distance and the number of trips of the rolling window
+type TierConfig struct {
+	SaplingMinDistance float64 `env:"TIER_SAPLING_MIN_DISTANCE" default:"200"`
+	SaplingMinTrips    int64   `env:"TIER_SAPLING_MIN_TRIPS" default:"20"`
+	ForestMinDistance  float64 `env:"TIER_FOREST_MIN_DISTANCE" default:"1000"`
+	ForestMinTrips     int64   `env:"TIER_FOREST_MIN_TRIPS" default:"100"`
+
+	// Period the distance and trips are counted over
+	Window time.Duration `env:"TIER_WINDOW" default:"8760h"`
+	// How long a member keeps a tier they no longer qualify for
+	DowngradeGrace time.Duration `env:"TIER_DOWNGRADE_GRACE" default:"720h"`
+	// How often members above the first tier are re-evaluated, as their window slides
+	EvaluationInterval  time.Duration `env:"TIER_EVALUATION_INTERVAL" default:"24h"`
+	EvaluationBatchSize int           `env:"TIER_EVALUATION_BATCH_SIZE" default:"500"`
+}
+
+// Tiers, lowest first
+const (
+	TierSeedling = "seedling"
+	TierSapling  = "sapling"
+	TierForest   = "forest"
+)
+
+// TierRank orders tiers, unknown ones ranking as the first tier
+func TierRank(tier string) int {
+	switch tier {
+	case TierForest:
+		return 2
+	case TierSapling:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Qualify returns the highest tier the distance and trips of a window reach
+func (c TierConfig) Qualify(distance float64, trips int64) string {
+	if distance >= c.ForestMinDistance && trips >= c.ForestMinTrips {
+		return TierForest
+	}
+	if distance >= c.SaplingMinDistance && trips >= c.SaplingMinTrips {
+		return TierSapling
+	}
+	return TierSeedling
+}
+
+// Thresholds returns the distance and trips needed for a tier
+func (c TierConfig) Thresholds(tier string) (float64, int64) {
+	switch tier {
+	case TierForest:
+		return c.ForestMinDistance, c.ForestMinTrips
+	case TierSapling:
+		return c.SaplingMinDistance, c.SaplingMinTrips
+	default:
+		return 0, 0
+	}
+}
+
+func (c TierConfig) validate() []string {
+	var problems []string
+
+	if c.SaplingMinDistance < 0 || c.SaplingMinTrips < 0 {
+		problems = append(problems, "TIER_SAPLING_MIN_* must not be negative")
+	}
+	if c.ForestMinDistance < c.SaplingMinDistance || c.ForestMinTrips < c.SaplingMinTrips {
+		problems = append(problems, "TIER_FOREST_MIN_* must be at least TIER_SAPLING_MIN_*")
+	}
+	if c.Window <= 0 {
+		problems = append(problems, "TIER_WINDOW must be positive")
+	}
+	if c.DowngradeGrace < 0 {
+		problems = append(problems, "TIER_DOWNGRADE_GRACE must not be negative")
+	}
+	if c.EvaluationInterval <= 0 {
+		problems = append(problems, "TIER_EVALUATION_INTERVAL must be positive")
+	}
+	if c.EvaluationBatchSize < 1 {
+		problems = append(problems, "TIER_EVALUATION_BATCH_SIZE must be at least 1")
+	}
+
+	return problems
+}
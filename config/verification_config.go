@@ -0,0 +1,35 @@
+package config
+
+import "time"
+
+// Length of the codes sent to confirm a new email or phone number, an
+// emergency contact or a password reset
+const VerificationCodeLength = 6
+
+type VerificationConfig struct {
+	CodeTTL     time.Duration `env:"VERIFICATION_CODE_TTL" default:"15m"`
+	MaxAttempts int           `env:"VERIFICATION_MAX_ATTEMPTS" default:"5"`
+	// How long the previous contact can undo a confirmed change
+	RevertTTL time.Duration `env:"VERIFICATION_REVERT_TTL" default:"168h"`
+	// Key of the HMAC codes and revert tokens are stored under
+	Secret string `env:"VERIFICATION_SECRET"`
+}
+
+func (c VerificationConfig) validate() []string {
+	var problems []string
+
+	if c.CodeTTL <= 0 {
+		problems = append(problems, "VERIFICATION_CODE_TTL must be positive")
+	}
+	if c.RevertTTL <= 0 {
+		problems = append(problems, "VERIFICATION_REVERT_TTL must be positive")
+	}
+	if c.MaxAttempts < 1 {
+		problems = append(problems, "VERIFICATION_MAX_ATTEMPTS must be at least 1")
+	}
+	if c.Secret == "" {
+		problems = append(problems, "VERIFICATION_SECRET is required")
+	}
+
+	return problems
+}
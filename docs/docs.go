@@ -0,0 +1,11 @@
+// Package docs embeds the generated API documents so the service can serve
+// them without the docs directory being deployed.
+package docs
+
+import _ "embed"
+
+//go:embed swagger.json
+var SwaggerJSON []byte
+
+//go:embed swagger.yaml
+var SwaggerYAML []byte
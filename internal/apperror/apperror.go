@@ -0,0 +1,162 @@
+// Package apperror defines the domain errors returned by the User Service and
+// how they map onto gRPC status codes and error details.
+package apperror
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain reported in every errdetails.ErrorInfo
+const Domain = "user-service.ecotaxi"
+
+// Reasons reported in errdetails.ErrorInfo, stable for clients to switch on
+const (
+	ReasonInvalidRequest       = "INVALID_REQUEST"
+	ReasonRequestTooLarge      = "REQUEST_TOO_LARGE"
+	ReasonUserNotFound         = "USER_NOT_FOUND"
+	ReasonPhoneNumberTaken     = "PHONE_NUMBER_TAKEN"
+	ReasonEmailTaken           = "EMAIL_TAKEN"
+	ReasonInvalidCredentials   = "INVALID_CREDENTIALS"
+	ReasonIncorrectPassword    = "INCORRECT_PASSWORD"
+	ReasonInvalidToken         = "INVALID_TOKEN"
+	ReasonSessionExpired       = "SESSION_EXPIRED"
+	ReasonNotAccountOwner      = "NOT_ACCOUNT_OWNER"
+	ReasonLimitExceeded        = "LIMIT_EXCEEDED"
+	ReasonNoPendingChange      = "NO_PENDING_CHANGE"
+	ReasonContactChanged       = "CONTACT_CHANGED"
+	ReasonInvalidCode          = "INVALID_VERIFICATION_CODE"
+	ReasonTooManyAttempts      = "TOO_MANY_ATTEMPTS"
+	ReasonVersionMismatch      = "VERSION_MISMATCH"
+	ReasonTripAlreadyRecorded  = "TRIP_ALREADY_RECORDED"
+	ReasonNegativeDistance     = "NEGATIVE_DISTANCE"
+	ReasonAdminRequired        = "ADMIN_REQUIRED"
+	ReasonServiceRequired      = "SERVICE_REQUIRED"
+	ReasonInsufficientPoints   = "INSUFFICIENT_POINTS"
+	ReasonIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED"
+	ReasonInvalidReferralCode  = "INVALID_REFERRAL_CODE"
+	ReasonSavedPlaceNotFound   = "SAVED_PLACE_NOT_FOUND"
+	ReasonPlaceTypeTaken       = "PLACE_TYPE_TAKEN"
+	ReasonContactNotFound      = "EMERGENCY_CONTACT_NOT_FOUND"
+	ReasonContactExists        = "EMERGENCY_CONTACT_EXISTS"
+	ReasonAlreadyVerified      = "ALREADY_VERIFIED"
+	ReasonNoCity               = "NO_CITY"
+	ReasonInternal             = "INTERNAL"
+)
+
+// FieldViolation describes one invalid field of a request
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Error is a domain error carrying the gRPC code it maps to. It implements
+// GRPCStatus, so handlers can return it as is.
+type Error struct {
+	Code       codes.Code
+	Reason     string
+	Message    string
+	Metadata   map[string]string
+	Violations []FieldViolation
+	// Underlying cause, logged but never sent to clients
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Converts the error into a gRPC status with ErrorInfo and, for invalid
+// arguments, BadRequest details
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.Code, e.Message)
+
+	info := &errdetails.ErrorInfo{Reason: e.Reason, Domain: Domain, Metadata: e.Metadata}
+	if len(e.Violations) == 0 {
+		if withDetails, err := st.WithDetails(info); err == nil {
+			return withDetails
+		}
+		return st
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, v := range e.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	if withDetails, err := st.WithDetails(info, badRequest); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// Replaces the ErrorInfo reason, for errors more specific than their constructor's default
+func (e *Error) WithReason(reason string) *Error {
+	e.Reason = reason
+	return e
+}
+
+// Adds metadata to the ErrorInfo detail
+func (e *Error) WithMetadata(key, value string) *Error {
+	if e.Metadata == nil {
+		e.Metadata = make(map[string]string)
+	}
+	e.Metadata[key] = value
+	return e
+}
+
+func InvalidArgument(message string, violations ...FieldViolation) *Error {
+	return &Error{Code: codes.InvalidArgument, Reason: ReasonInvalidRequest, Message: message, Violations: violations}
+}
+
+func NotFound(reason, message string) *Error {
+	return &Error{Code: codes.NotFound, Reason: reason, Message: message}
+}
+
+func AlreadyExists(reason, message string) *Error {
+	return &Error{Code: codes.AlreadyExists, Reason: reason, Message: message}
+}
+
+func Unauthenticated(reason, message string) *Error {
+	return &Error{Code: codes.Unauthenticated, Reason: reason, Message: message}
+}
+
+func PermissionDenied(reason, message string) *Error {
+	return &Error{Code: codes.PermissionDenied, Reason: reason, Message: message}
+}
+
+func ResourceExhausted(reason, message string) *Error {
+	return &Error{Code: codes.ResourceExhausted, Reason: reason, Message: message}
+}
+
+func FailedPrecondition(reason, message string) *Error {
+	return &Error{Code: codes.FailedPrecondition, Reason: reason, Message: message}
+}
+
+func Aborted(reason, message string) *Error {
+	return &Error{Code: codes.Aborted, Reason: reason, Message: message}
+}
+
+// Wraps an unexpected failure; the cause is kept for logs only
+func Internal(err error) *Error {
+	return &Error{Code: codes.Internal, Reason: ReasonInternal, Message: "Internal server error", Err: err}
+}
+
+// Reports whether err is a domain error with the given reason
+func HasReason(err error, reason string) bool {
+	var appErr *Error
+	return errors.As(err, &appErr) && appErr.Reason == reason
+}
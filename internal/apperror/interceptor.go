@@ -0,0 +1,42 @@
+package apperror
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Makes sure no raw error leaks to clients: errors that are neither domain
+// errors nor gRPC statuses are logged and replaced by a generic Internal error.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+	if err == nil {
+		return res, nil
+	}
+
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		if appErr.Code == codes.Internal {
+			log.Printf("%s failed: %v", info.FullMethod, err)
+		}
+		return res, appErr
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return res, err
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return nil, status.Error(codes.Canceled, "Request canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, status.Error(codes.DeadlineExceeded, "Request deadline exceeded")
+	}
+
+	log.Printf("%s failed: %v", info.FullMethod, err)
+	return nil, Internal(err)
+}
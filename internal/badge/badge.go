@@ -0,0 +1,106 @@
+// Package badge awards the badges defined in the configuration once a
+// user's metrics reach their thresholds.
+package badge
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/event"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/streak"
+)
+
+// Metrics holds the value of every badge metric for one user
+type Metrics map[string]float64
+
+// Measure computes the user's badge metrics from the distance ledger
+func Measure(ctx context.Context, userId uint64, now time.Time) (Metrics, error) {
+	distanceRepo := repository.NewDistanceRepo(config.DB)
+	emissions := config.AppConfig.Emissions
+
+	sums, err := distanceRepo.SumByVehicleType(ctx, userId, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := Metrics{}
+	for _, sum := range sums {
+		metrics[config.BadgeMetricDistance] += sum.Distance
+		metrics[config.BadgeMetricCO2Saved] += sum.Distance * emissions.SavedPerKm(sum.Kind, sum.VehicleType)
+	}
+
+	trips, err := distanceRepo.CountTrips(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	metrics[config.BadgeMetricTrips] = float64(trips)
+
+	// Only the days a streak badge can still count are read
+	if longest := config.AppConfig.Badges.MaxStreak(); longest > 0 {
+		user := model.User{Id: userId}
+		if err := repository.NewUserRepo(config.DB).GetUser(ctx, &user); err != nil {
+			return nil, err
+		}
+
+		s, err := streak.Load(ctx, &user, now, time.Duration(longest+1)*24*time.Hour)
+		if err != nil {
+			return nil, err
+		}
+		metrics[config.BadgeMetricStreakDays] = float64(s.Daily)
+	}
+
+	return metrics, nil
+}
+
+// Evaluate awards every badge whose threshold the user's metrics reach and
+// publishes an event for each new one. It returns the metrics and all the
+// badges the user has.
+func Evaluate(ctx context.Context, userId uint64, now time.Time) (Metrics, []model.UserBadge, error) {
+	metrics, err := Measure(ctx, userId, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	badgeRepo := repository.NewBadgeRepo(config.DB)
+	for _, badge := range reached(config.AppConfig.Badges.Definitions, metrics) {
+		awarded, err := badgeRepo.Award(ctx, userId, badge.Code, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !awarded {
+			continue
+		}
+
+		err = event.Publish(ctx, event.Event{
+			Type:       event.BadgeAwarded,
+			UserId:     userId,
+			OccurredAt: now,
+			Data:       map[string]string{"badge_code": badge.Code},
+		})
+		if err != nil {
+			log.Printf("Failed to publish badge %s of user %d: %v", badge.Code, userId, err)
+		}
+	}
+
+	badges, err := badgeRepo.List(ctx, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return metrics, badges, nil
+}
+
+// Returns the badges whose threshold the metrics reach
+func reached(definitions []config.BadgeDefinition, metrics Metrics) []config.BadgeDefinition {
+	var badges []config.BadgeDefinition
+	for _, badge := range definitions {
+		if metrics[badge.Metric] >= badge.Threshold {
+			badges = append(badges, badge)
+		}
+	}
+	return badges
+}
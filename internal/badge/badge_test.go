@@ -0,0 +1,152 @@
+package badge
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/testdb"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestReached(t *testing.T) {
+	definitions := []config.BadgeDefinition{
+		{Code: "first_10_km", Metric: config.BadgeMetricDistance, Threshold: 10},
+		{Code: "first_100_km", Metric: config.BadgeMetricDistance, Threshold: 100},
+		{Code: "ten_trips", Metric: config.BadgeMetricTrips, Threshold: 10},
+		{Code: "one_kg_saved", Metric: config.BadgeMetricCO2Saved, Threshold: 1000},
+		{Code: "streak_7_days", Metric: config.BadgeMetricStreakDays, Threshold: 7},
+	}
+
+	tests := []struct {
+		name    string
+		metrics Metrics
+		want    []string
+	}{
+		{
+			name: "no activity",
+		},
+		{
+			name:    "below every threshold",
+			metrics: Metrics{config.BadgeMetricDistance: 9.9, config.BadgeMetricTrips: 9, config.BadgeMetricStreakDays: 6},
+		},
+		{
+			name:    "threshold reached exactly",
+			metrics: Metrics{config.BadgeMetricDistance: 10, config.BadgeMetricTrips: 10},
+			want:    []string{"first_10_km", "ten_trips"},
+		},
+		{
+			name:    "every threshold of a metric passed",
+			metrics: Metrics{config.BadgeMetricDistance: 250},
+			want:    []string{"first_10_km", "first_100_km"},
+		},
+		{
+			name: "all badges",
+			metrics: Metrics{
+				config.BadgeMetricDistance:   100,
+				config.BadgeMetricTrips:      12,
+				config.BadgeMetricCO2Saved:   1500,
+				config.BadgeMetricStreakDays: 30,
+			},
+			want: []string{"first_10_km", "first_100_km", "ten_trips", "one_kg_saved", "streak_7_days"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var codes []string
+			for _, badge := range reached(definitions, test.metrics) {
+				codes = append(codes, badge.Code)
+			}
+			if !slices.Equal(codes, test.want) {
+				t.Errorf("reached() = %v, want %v", codes, test.want)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	ctx := context.Background()
+	config.DB = testdb.Open(t)
+	// Events are only logged when they cannot be published
+	config.Redis = redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	t.Cleanup(func() { config.Redis.Close() })
+	config.AppConfig = &config.Config{
+		Emissions: config.EmissionsConfig{EVSavedPerKm: 120, HybridSavedPerKm: 60, DefaultVehicleType: config.VehicleTypeEV},
+		Events:    config.EventsConfig{Stream: "events", StreamMaxLen: 10},
+		Badges: config.BadgeConfig{Definitions: []config.BadgeDefinition{
+			{Code: "first_trip_day", Metric: config.BadgeMetricStreakDays, Threshold: 1},
+			{Code: "first_10_km", Metric: config.BadgeMetricDistance, Threshold: 10},
+			{Code: "two_trips", Metric: config.BadgeMetricTrips, Threshold: 2},
+			{Code: "one_kg_saved", Metric: config.BadgeMetricCO2Saved, Threshold: 1000},
+			{Code: "first_100_km", Metric: config.BadgeMetricDistance, Threshold: 100},
+		}},
+	}
+
+	user := &model.User{Name: "Jane", PhoneNumber: "+6591234567", Email: "jane@example.com", Password: "hash"}
+	if err := config.DB.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	distanceRepo := repository.NewDistanceRepo(config.DB)
+	recordTrip := func(tripId, vehicleType string, distance float64) {
+		t.Helper()
+		if _, err := distanceRepo.RecordTrip(ctx, user.Id, tripId, vehicleType, distance, 0, &repository.PointsChange{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	evaluate := func(now time.Time, wantMetrics Metrics, wantCodes ...string) []model.UserBadge {
+		t.Helper()
+		metrics, badges, err := Evaluate(ctx, user.Id, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !maps.Equal(metrics, wantMetrics) {
+			t.Errorf("Evaluate() metrics = %v, want %v", metrics, wantMetrics)
+		}
+		var codes []string
+		for _, badge := range badges {
+			codes = append(codes, badge.BadgeCode)
+		}
+		if !slices.Equal(codes, wantCodes) {
+			t.Errorf("Evaluate() badges = %v, want %v", codes, wantCodes)
+		}
+		return badges
+	}
+
+	first := time.Now().Truncate(time.Millisecond)
+	recordTrip("trip-1", config.VehicleTypeEV, 6)
+	firstBadges := evaluate(first, Metrics{
+		config.BadgeMetricDistance:   6,
+		config.BadgeMetricTrips:      1,
+		config.BadgeMetricCO2Saved:   720,
+		config.BadgeMetricStreakDays: 1,
+	}, "first_trip_day")
+
+	recordTrip("trip-2", config.VehicleTypeHybrid, 5)
+	secondMetrics := Metrics{
+		config.BadgeMetricDistance:   11,
+		config.BadgeMetricTrips:      2,
+		config.BadgeMetricCO2Saved:   1020,
+		config.BadgeMetricStreakDays: 1,
+	}
+	evaluate(first.Add(time.Minute), secondMetrics, "first_trip_day", "first_10_km", "two_trips", "one_kg_saved")
+
+	// Nothing changed, nothing is awarded again
+	badges := evaluate(first.Add(2*time.Minute), secondMetrics, "first_trip_day", "first_10_km", "two_trips", "one_kg_saved")
+	if !badges[0].AwardedAt.Equal(firstBadges[0].AwardedAt) {
+		t.Errorf("first_trip_day awarded at %v, then again at %v", firstBadges[0].AwardedAt, badges[0].AwardedAt)
+	}
+
+	awarded, err := repository.NewBadgeRepo(config.DB).Award(ctx, user.Id, "first_10_km", first.Add(3*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if awarded {
+		t.Error("Award() of a badge the user has = true, want false")
+	}
+}
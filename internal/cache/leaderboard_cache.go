@@ -0,0 +1,175 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Leaderboards, each holding one sorted set per period
+const (
+	BoardWeekly  = "weekly"
+	BoardMonthly = "monthly"
+	BoardAllTime = "all_time"
+)
+
+var Boards = []string{BoardWeekly, BoardMonthly, BoardAllTime}
+
+// How long the sorted set of a past period is kept
+var boardRetention = map[string]time.Duration{
+	BoardWeekly:  5 * 7 * 24 * time.Hour,
+	BoardMonthly: 13 * 31 * 24 * time.Hour,
+}
+
+// Set once the leaderboards were built, disappears with them when Redis is flushed
+const leaderboardBuiltKey = "leaderboard:built"
+
+type leaderboardCache struct {
+	rdb *redis.Client
+}
+
+func NewLeaderboardCache(rdb *redis.Client) *leaderboardCache {
+	return &leaderboardCache{
+		rdb: rdb,
+	}
+}
+
+// Adds score to the user on every board of the periods containing at, the
+// global ones and those of city unless it is empty
+func (l *leaderboardCache) Add(ctx context.Context, userId uint64, city string, score float64, at time.Time) error {
+	member := strconv.FormatUint(userId, 10)
+
+	_, err := l.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, board := range Boards {
+			for _, key := range l.userBoardKeys(board, city, at) {
+				pipe.ZIncrBy(ctx, key, score, member)
+				if retention, ok := boardRetention[board]; ok {
+					pipe.Expire(ctx, key, retention)
+				}
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// Sets the user's score on the board of the period containing now, of city
+// or the global one when city is empty
+func (l *leaderboardCache) Set(ctx context.Context, board, city string, now time.Time, userId uint64, score float64) error {
+	return l.rdb.ZAdd(ctx, l.boardKey(board, city, now), redis.Z{Score: score, Member: strconv.FormatUint(userId, 10)}).Err()
+}
+
+// Removes the user from the boards of the periods containing now, the global
+// ones and those of city unless it is empty
+func (l *leaderboardCache) Remove(ctx context.Context, userId uint64, city string, now time.Time) error {
+	member := strconv.FormatUint(userId, 10)
+
+	_, err := l.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, board := range Boards {
+			for _, key := range l.userBoardKeys(board, city, now) {
+				pipe.ZRem(ctx, key, member)
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// Returns limit users from the offset-th best, best first
+func (l *leaderboardCache) Top(ctx context.Context, board, city string, now time.Time, offset, limit int64) ([]redis.Z, error) {
+	return l.rdb.ZRevRangeWithScores(ctx, l.boardKey(board, city, now), offset, offset+limit-1).Result()
+}
+
+// Returns the 0-based rank and score of the user and the number of ranked
+// users. Returns redis.Nil, with the number of ranked users, when the user is
+// not ranked.
+func (l *leaderboardCache) Rank(ctx context.Context, board, city string, now time.Time, userId uint64) (int64, float64, int64, error) {
+	key := l.boardKey(board, city, now)
+	member := strconv.FormatUint(userId, 10)
+
+	var rank *redis.IntCmd
+	var score *redis.FloatCmd
+	var total *redis.IntCmd
+	_, err := l.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		rank = pipe.ZRevRank(ctx, key, member)
+		score = pipe.ZScore(ctx, key, member)
+		total = pipe.ZCard(ctx, key)
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, 0, 0, err
+	}
+	if errors.Is(rank.Err(), redis.Nil) {
+		return 0, 0, total.Val(), redis.Nil
+	}
+
+	return rank.Val(), score.Val(), total.Val(), nil
+}
+
+// Replaces the board of the period containing now with the given scores
+func (l *leaderboardCache) Replace(ctx context.Context, board, city string, now time.Time, scores map[uint64]float64) error {
+	key := l.boardKey(board, city, now)
+	if len(scores) == 0 {
+		return l.rdb.Del(ctx, key).Err()
+	}
+
+	members := make([]redis.Z, 0, len(scores))
+	for userId, score := range scores {
+		members = append(members, redis.Z{Score: score, Member: strconv.FormatUint(userId, 10)})
+	}
+
+	// Built aside and renamed so readers never see a partial board
+	tmpKey := key + ":rebuild"
+	_, err := l.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, tmpKey)
+		pipe.ZAdd(ctx, tmpKey, members...)
+		pipe.Rename(ctx, tmpKey, key)
+		if retention, ok := boardRetention[board]; ok {
+			pipe.Expire(ctx, key, retention)
+		}
+		return nil
+	})
+	return err
+}
+
+// Reports whether the boards were built since Redis was last flushed
+func (l *leaderboardCache) IsBuilt(ctx context.Context) (bool, error) {
+	n, err := l.rdb.Exists(ctx, leaderboardBuiltKey).Result()
+	return n > 0, err
+}
+
+func (l *leaderboardCache) MarkBuilt(ctx context.Context) error {
+	return l.rdb.Set(ctx, leaderboardBuiltKey, time.Now().Format(time.RFC3339), 0).Err()
+}
+
+// Keys of the boards a user of city is ranked on
+func (l *leaderboardCache) userBoardKeys(board, city string, t time.Time) []string {
+	keys := []string{l.boardKey(board, "", t)}
+	if city != "" {
+		keys = append(keys, l.boardKey(board, city, t))
+	}
+	return keys
+}
+
+// Key of the sorted set of a board for the period containing t, e.g.
+// leaderboard:weekly:2024-W05 or leaderboard:city:hanoi:monthly:2024-02
+func (l *leaderboardCache) boardKey(board, city string, t time.Time) string {
+	prefix := "leaderboard:"
+	if city != "" {
+		prefix += "city:" + city + ":"
+	}
+
+	switch board {
+	case BoardWeekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%s%s:%d-W%02d", prefix, board, year, week)
+	case BoardMonthly:
+		return fmt.Sprintf("%s%s:%s", prefix, board, t.Format("2006-01"))
+	default:
+		return prefix + board
+	}
+}
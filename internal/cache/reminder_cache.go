@@ -0,0 +1,28 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Outlives the day a reminder is sent for in every time zone
+const reminderTTL = 48 * time.Hour
+
+type reminderCache struct {
+	rdb *redis.Client
+}
+
+func NewReminderCache(rdb *redis.Client) *reminderCache {
+	return &reminderCache{
+		rdb: rdb,
+	}
+}
+
+// Records that the user is reminded of their streak on a day (YYYY-MM-DD in
+// their time zone) and reports whether they were not already
+func (r *reminderCache) MarkStreakReminded(ctx context.Context, userId uint64, day string) (bool, error) {
+	return r.rdb.SetNX(ctx, fmt.Sprintf("streak_reminder:%d:%s", userId, day), 1, reminderTTL).Result()
+}
@@ -2,90 +2,152 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
-type sessionCache struct {
-    rdb *redis.Client
-}
+var ErrSessionExpired = errors.New("session expired")
 
-func NewSessionCache(rdb *redis.Client) *sessionCache {
-    return &sessionCache{
-		rdb: rdb,
-	}
+// Session is the server side state behind a refresh token
+type Session struct {
+	UserId      uint64
+	ClientType  string
+	RememberMe  bool
+	CreatedAt   time.Time
+	IdleTimeout time.Duration
+	MaxAge      time.Duration
 }
 
-// Stores the refresh token associated with a user
-func (s *sessionCache) StoreRefreshToken(ctx context.Context, userId uint64, token string) error {
-    // Store user ID to token mapping
-    if err := s.rdb.Set(ctx, s.refreshTokenKey(userId), token, 24*time.Hour).Err(); err != nil {
-        return err
-    }
-    // Store token to user ID mapping
-    return s.rdb.Set(ctx, s.userIdKey(token), userId, 24*time.Hour).Err()
+// Returns how long the session may stay idle from now on, capped by its absolute max age
+func (s *Session) remainingTTL(now time.Time) time.Duration {
+	remaining := s.CreatedAt.Add(s.MaxAge).Sub(now)
+	if remaining < s.IdleTimeout {
+		return remaining
+	}
+	return s.IdleTimeout
 }
 
-// Retrieves the refresh token for a user
-func (s *sessionCache) GetRefreshToken(ctx context.Context, userId uint64) (string, error) {
-    return s.rdb.Get(ctx, s.refreshTokenKey(userId)).Result()
+type sessionCache struct {
+	rdb *redis.Client
 }
 
-// Deletes the refresh token for a user
-func (s *sessionCache) DeleteRefreshToken(ctx context.Context, userId uint64) error {
-    token, err := s.GetRefreshToken(ctx, userId)
-    if err == nil {
-        // Delete token-to-user ID mapping as well
-        _ = s.rdb.Del(ctx, s.userIdKey(token)).Err()
-    }
-    return s.rdb.Del(ctx, s.refreshTokenKey(userId)).Err()
+func NewSessionCache(rdb *redis.Client) *sessionCache {
+	return &sessionCache{
+		rdb: rdb,
+	}
 }
 
-// Retrieves the user ID from a given token
-func (s *sessionCache) GetUserIdFromRefreshToken(ctx context.Context, token string) (uint64, error) {
-    userIdStr, err := s.rdb.Get(ctx, s.userIdKey(token)).Result()
-    if err != nil {
-        return 0, err
-    }
-    userId, err := strconv.ParseUint(userIdStr, 10, 64)
-    if err != nil {
-        return 0, err
-    }
-    return userId, nil
-}
+// Stores the refresh token and its session, replacing any previous session of the same client type
+func (s *sessionCache) StoreRefreshToken(ctx context.Context, token string, session *Session) error {
+	ttl := session.remainingTTL(time.Now())
+	if ttl <= 0 {
+		return ErrSessionExpired
+	}
 
-// Key for storing refresh token by user ID
-func (s *sessionCache) refreshTokenKey(userId uint64) string {
-    return "refresh_token_with_user_id:" + strconv.FormatUint(userId, 10)
-}
+	// Drop the session this login replaces so its refresh token stops working
+	if oldToken, err := s.rdb.Get(ctx, s.refreshTokenKey(session.UserId, session.ClientType)).Result(); err == nil {
+		_ = s.rdb.Del(ctx, s.sessionKey(oldToken)).Err()
+	}
 
-// Key for storing user ID by token
-func (s *sessionCache) userIdKey(token string) string {
-    return "user_id_from_token:" + token
+	_, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		// Store user ID and client type to token mapping
+		pipe.Set(ctx, s.refreshTokenKey(session.UserId, session.ClientType), token, ttl)
+		pipe.SAdd(ctx, s.clientTypesKey(session.UserId), session.ClientType)
+		pipe.Expire(ctx, s.clientTypesKey(session.UserId), session.MaxAge)
+		// Store token to session mapping
+		pipe.HSet(ctx, s.sessionKey(token), map[string]interface{}{
+			"user_id":      session.UserId,
+			"client_type":  session.ClientType,
+			"remember_me":  session.RememberMe,
+			"created_at":   session.CreatedAt.Unix(),
+			"idle_timeout": int64(session.IdleTimeout),
+			"max_age":      int64(session.MaxAge),
+		})
+		pipe.Expire(ctx, s.sessionKey(token), ttl)
+		return nil
+	})
+	return err
 }
 
+// Retrieves the session behind a refresh token
+func (s *sessionCache) GetSession(ctx context.Context, token string) (*Session, error) {
+	values, err := s.rdb.HGetAll(ctx, s.sessionKey(token)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, redis.Nil
+	}
 
+	userId, err := strconv.ParseUint(values["user_id"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	rememberMe, _ := strconv.ParseBool(values["remember_me"])
+	createdAt, _ := strconv.ParseInt(values["created_at"], 10, 64)
+	idleTimeout, _ := strconv.ParseInt(values["idle_timeout"], 10, 64)
+	maxAge, _ := strconv.ParseInt(values["max_age"], 10, 64)
+
+	return &Session{
+		UserId:      userId,
+		ClientType:  values["client_type"],
+		RememberMe:  rememberMe,
+		CreatedAt:   time.Unix(createdAt, 0),
+		IdleTimeout: time.Duration(idleTimeout),
+		MaxAge:      time.Duration(maxAge),
+	}, nil
+}
 
+// Slides the idle timeout of a session forward, failing once its absolute max age is reached
+func (s *sessionCache) TouchSession(ctx context.Context, token string, session *Session) error {
+	ttl := session.remainingTTL(time.Now())
+	if ttl <= 0 {
+		_ = s.rdb.Del(ctx, s.sessionKey(token), s.refreshTokenKey(session.UserId, session.ClientType)).Err()
+		return ErrSessionExpired
+	}
 
+	_, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Expire(ctx, s.sessionKey(token), ttl)
+		pipe.Expire(ctx, s.refreshTokenKey(session.UserId, session.ClientType), ttl)
+		return nil
+	})
+	return err
+}
 
+// Deletes every refresh token of a user
+func (s *sessionCache) DeleteRefreshToken(ctx context.Context, userId uint64) error {
+	clientTypes, err := s.rdb.SMembers(ctx, s.clientTypesKey(userId)).Result()
+	if err != nil {
+		return err
+	}
 
+	for _, clientType := range clientTypes {
+		token, err := s.rdb.Get(ctx, s.refreshTokenKey(userId, clientType)).Result()
+		if err == nil {
+			// Delete token-to-session mapping as well
+			_ = s.rdb.Del(ctx, s.sessionKey(token)).Err()
+		}
+		if err := s.rdb.Del(ctx, s.refreshTokenKey(userId, clientType)).Err(); err != nil {
+			return err
+		}
+	}
+	return s.rdb.Del(ctx, s.clientTypesKey(userId)).Err()
+}
 
-// func (s *sessionCache) StoreRefreshToken(ctx context.Context, userId uint64, token string) error {
-//     return s.rdb.Set(ctx, s.refreshTokenKey(userId), token, 24*time.Hour).Err()
-// }
-
-// func (s *sessionCache) GetRefreshToken(ctx context.Context, userId uint64) (string, error) {
-//     return s.rdb.Get(ctx, s.refreshTokenKey(userId)).Result()
-// }
-
-
+// Key for storing refresh token by user ID and client type
+func (s *sessionCache) refreshTokenKey(userId uint64, clientType string) string {
+	return "refresh_token_with_user_id:" + strconv.FormatUint(userId, 10) + ":" + clientType
+}
 
-// func (s *sessionCache) DeleteRefreshToken(ctx context.Context, userId uint64) error {
-//     return s.rdb.Del(ctx, s.refreshTokenKey(userId)).Err()
-// }
+// Key for storing the client types a user has sessions on
+func (s *sessionCache) clientTypesKey(userId uint64) string {
+	return "session_client_types:" + strconv.FormatUint(userId, 10)
+}
 
-// func (s *sessionCache) refreshTokenKey(userId uint64) string {
-//     return "refresh_token:" + strconv.FormatUint(userId, 10)
-// }
+// Key for storing session by token
+func (s *sessionCache) sessionKey(token string) string {
+	return "session_from_token:" + token
+}
@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrInvalidCode     = errors.New("invalid verification code")
+	ErrTooManyAttempts = errors.New("too many verification attempts")
+)
+
+// PendingChange is a contact change whose code was checked
+type PendingChange struct {
+	Value string
+	// Version of the user the change was requested at, 0 when not checked
+	ExpectedVersion uint64
+}
+
+// ContactRevert is the contact change a revert link undoes
+type ContactRevert struct {
+	UserId   uint64
+	Kind     string
+	OldValue string
+	NewValue string
+}
+
+type verificationCache struct {
+	rdb *redis.Client
+}
+
+func NewVerificationCache(rdb *redis.Client) *verificationCache {
+	return &verificationCache{
+		rdb: rdb,
+	}
+}
+
+// Stores a contact change waiting for its code, replacing any previous one of
+// the same kind. expectedVersion is the user version it must still apply to, 0
+// for any.
+func (v *verificationCache) StorePendingChange(ctx context.Context, userId uint64, kind, value, codeHash string, expectedVersion uint64, ttl time.Duration) error {
+	key := v.pendingChangeKey(userId, kind)
+
+	_, err := v.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, map[string]interface{}{
+			"value":     value,
+			"code_hash": codeHash,
+			"attempts":  0,
+			"version":   expectedVersion,
+		})
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	return err
+}
+
+// Compares the code hash with the pending change and counts a wrong one as an
+// attempt, dropping the change at the limit, in one step so parallel guesses
+// cannot get past it. Returns {status, value, version}.
+var checkPendingChangeScript = redis.NewScript(`
+local pending = redis.call("HMGET", KEYS[1], "value", "code_hash", "version")
+if not pending[1] then
+	return {"missing", "", "0"}
+end
+if pending[2] == ARGV[1] then
+	return {"ok", pending[1], pending[3] or "0"}
+end
+local attempts = redis.call("HINCRBY", KEYS[1], "attempts", 1)
+if attempts >= tonumber(ARGV[2]) then
+	redis.call("DEL", KEYS[1])
+	return {"too_many", "", "0"}
+end
+return {"invalid", "", "0"}
+`)
+
+// Checks a code against the pending change and returns the change it
+// confirms. Wrong codes count as attempts; the change is dropped once
+// maxAttempts is reached. Returns redis.Nil when nothing is pending.
+func (v *verificationCache) CheckPendingChange(ctx context.Context, userId uint64, kind, codeHash string, maxAttempts int) (*PendingChange, error) {
+	key := v.pendingChangeKey(userId, kind)
+
+	result, err := checkPendingChangeScript.Run(ctx, v.rdb, []string{key}, codeHash, maxAttempts).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	switch result[0] {
+	case "ok":
+		version, err := strconv.ParseUint(result[2], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &PendingChange{Value: result[1], ExpectedVersion: version}, nil
+	case "missing":
+		return nil, redis.Nil
+	case "too_many":
+		return nil, ErrTooManyAttempts
+	default:
+		return nil, ErrInvalidCode
+	}
+}
+
+// Sets the version a pending change expects, if it still expects fromVersion
+var rebasePendingChangeScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], "version") == ARGV[1] then
+	redis.call("HSET", KEYS[1], "version", ARGV[2])
+end
+return 0
+`)
+
+// Moves a pending change expecting fromVersion to toVersion, for changes the
+// user made alongside it, such as confirming the other contact of one request
+func (v *verificationCache) RebasePendingChange(ctx context.Context, userId uint64, kind string, fromVersion, toVersion uint64) error {
+	key := v.pendingChangeKey(userId, kind)
+	return rebasePendingChangeScript.Run(ctx, v.rdb, []string{key}, strconv.FormatUint(fromVersion, 10), strconv.FormatUint(toVersion, 10)).Err()
+}
+
+// Deletes the pending change of a kind
+func (v *verificationCache) DeletePendingChange(ctx context.Context, userId uint64, kind string) error {
+	return v.rdb.Del(ctx, v.pendingChangeKey(userId, kind)).Err()
+}
+
+// Stores what a revert link undoes, keyed by the hash of its token
+func (v *verificationCache) StoreRevert(ctx context.Context, tokenHash string, revert *ContactRevert, ttl time.Duration) error {
+	key := v.revertKey(tokenHash)
+
+	_, err := v.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, map[string]interface{}{
+			"user_id":   revert.UserId,
+			"kind":      revert.Kind,
+			"old_value": revert.OldValue,
+			"new_value": revert.NewValue,
+		})
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	return err
+}
+
+// Returns the change a revert token undoes. Returns redis.Nil for unknown or
+// expired tokens.
+func (v *verificationCache) GetRevert(ctx context.Context, tokenHash string) (*ContactRevert, error) {
+	values, err := v.rdb.HGetAll(ctx, v.revertKey(tokenHash)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, redis.Nil
+	}
+
+	userId, err := strconv.ParseUint(values["user_id"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContactRevert{
+		UserId:   userId,
+		Kind:     values["kind"],
+		OldValue: values["old_value"],
+		NewValue: values["new_value"],
+	}, nil
+}
+
+// Deletes a revert token once the change it undoes is reverted
+func (v *verificationCache) DeleteRevert(ctx context.Context, tokenHash string) error {
+	return v.rdb.Del(ctx, v.revertKey(tokenHash)).Err()
+}
+
+// Key for storing a pending contact change by user ID and kind (email, phone_number)
+func (v *verificationCache) pendingChangeKey(userId uint64, kind string) string {
+	return "pending_contact_change:" + kind + ":" + strconv.FormatUint(userId, 10)
+}
+
+// Key for storing a contact change revert by token hash
+func (v *verificationCache) revertKey(tokenHash string) string {
+	return "contact_change_revert:" + tokenHash
+}
@@ -0,0 +1,9 @@
+// Package distancekind names the kinds of distance entries. It imports
+// nothing, so config can tell them apart without depending on the model.
+package distancekind
+
+// Values of distance_entries.kind
+const (
+	Trip       = "trip"
+	Adjustment = "adjustment"
+)
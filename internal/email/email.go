@@ -0,0 +1,75 @@
+// Package email renders the emails sent to users in their language.
+package email
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"path"
+	"strings"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/utils"
+)
+
+// Emails, each defined as "<name>.subject" and "<name>.body" in every language
+const (
+	// Data: Name, Link
+	VerifyEmail = "verify_email"
+	// Data: Name, Code, Minutes
+	ResetPassword = "reset_password"
+	// Data: Name, Code, Minutes
+	ConfirmEmailChange = "confirm_email_change"
+	// Data: Name, NewEmail, Link, Days
+	EmailChanged = "email_changed"
+	// Data: Days, Link
+	StreakReminder = "streak_reminder"
+)
+
+// One file per language, named after it
+//
+//go:embed templates/*.html
+var files embed.FS
+
+var templates = parse()
+
+func parse() map[string]*template.Template {
+	names, err := files.ReadDir("templates")
+	if err != nil {
+		panic(err)
+	}
+
+	parsed := map[string]*template.Template{}
+	for _, name := range names {
+		language := strings.TrimSuffix(name.Name(), ".html")
+		parsed[language] = template.Must(template.ParseFS(files, path.Join("templates", name.Name())))
+	}
+	return parsed
+}
+
+// Render returns the subject and body of an email in the language, or in
+// English when it is not written in that language
+func Render(language, name string, data any) (string, string, error) {
+	t, ok := templates[language]
+	if !ok || t.Lookup(name+".subject") == nil {
+		t = templates[config.LanguageEnglish]
+	}
+
+	var subject, body bytes.Buffer
+	if err := t.ExecuteTemplate(&subject, name+".subject", data); err != nil {
+		return "", "", err
+	}
+	if err := t.ExecuteTemplate(&body, name+".body", data); err != nil {
+		return "", "", err
+	}
+	return subject.String(), body.String(), nil
+}
+
+// Send renders an email in the language and sends it
+func Send(to, language, name string, data any) error {
+	subject, body, err := Render(language, name, data)
+	if err != nil {
+		return err
+	}
+	return utils.SendEmail(to, subject, body)
+}
@@ -0,0 +1,49 @@
+// Package event publishes domain events to a Redis stream other services
+// consume, e.g. to apply the perks of a membership tier.
+package event
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// Event types
+const (
+	TierChanged      = "membership.tier_changed"
+	BadgeAwarded     = "badge.awarded"
+	ReferralRewarded = "referral.rewarded"
+)
+
+// Event is one entry of the stream. Data holds the fields specific to its type.
+type Event struct {
+	Type       string
+	UserId     uint64
+	OccurredAt time.Time
+	Data       map[string]string
+}
+
+// Publish appends the event to the configured stream. Events report changes
+// that are already committed, so callers only log a failure: a lost event
+// must not undo the change.
+func Publish(ctx context.Context, e Event) error {
+	values := map[string]interface{}{
+		"type":        e.Type,
+		"user_id":     strconv.FormatUint(e.UserId, 10),
+		"occurred_at": e.OccurredAt.UTC().Format(time.RFC3339Nano),
+	}
+	for key, value := range e.Data {
+		values[key] = value
+	}
+
+	events := config.AppConfig.Events
+	return config.Redis.XAdd(ctx, &redis.XAddArgs{
+		Stream: events.Stream,
+		MaxLen: events.StreamMaxLen,
+		Approx: true,
+		Values: values,
+	}).Err()
+}
@@ -19,16 +19,52 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_SignUp_FullMethodName                  = "/user_service.UserService/SignUp"
-	UserService_LogIn_FullMethodName                   = "/user_service.UserService/LogIn"
-	UserService_LogOut_FullMethodName                  = "/user_service.UserService/LogOut"
-	UserService_ForgotPassword_FullMethodName          = "/user_service.UserService/ForgotPassword"
-	UserService_UpdateUser_FullMethodName              = "/user_service.UserService/UpdateUser"
-	UserService_GetUser_FullMethodName                 = "/user_service.UserService/GetUser"
-	UserService_ChangePassword_FullMethodName          = "/user_service.UserService/ChangePassword"
-	UserService_UpdateDistanceTravelled_FullMethodName = "/user_service.UserService/UpdateDistanceTravelled"
-	UserService_AuthenticateUser_FullMethodName        = "/user_service.UserService/AuthenticateUser"
-	UserService_RefreshToken_FullMethodName            = "/user_service.UserService/RefreshToken"
+	UserService_SignUp_FullMethodName                     = "/user_service.UserService/SignUp"
+	UserService_LogIn_FullMethodName                      = "/user_service.UserService/LogIn"
+	UserService_LogOut_FullMethodName                     = "/user_service.UserService/LogOut"
+	UserService_ForgotPassword_FullMethodName             = "/user_service.UserService/ForgotPassword"
+	UserService_ResetPassword_FullMethodName              = "/user_service.UserService/ResetPassword"
+	UserService_UpdateUser_FullMethodName                 = "/user_service.UserService/UpdateUser"
+	UserService_RequestEmailChange_FullMethodName         = "/user_service.UserService/RequestEmailChange"
+	UserService_ConfirmEmailChange_FullMethodName         = "/user_service.UserService/ConfirmEmailChange"
+	UserService_RequestPhoneChange_FullMethodName         = "/user_service.UserService/RequestPhoneChange"
+	UserService_ConfirmPhoneChange_FullMethodName         = "/user_service.UserService/ConfirmPhoneChange"
+	UserService_RevertContactChange_FullMethodName        = "/user_service.UserService/RevertContactChange"
+	UserService_GetUser_FullMethodName                    = "/user_service.UserService/GetUser"
+	UserService_ChangePassword_FullMethodName             = "/user_service.UserService/ChangePassword"
+	UserService_UpdateDistanceTravelled_FullMethodName    = "/user_service.UserService/UpdateDistanceTravelled"
+	UserService_ListDistanceEntries_FullMethodName        = "/user_service.UserService/ListDistanceEntries"
+	UserService_AdjustDistance_FullMethodName             = "/user_service.UserService/AdjustDistance"
+	UserService_GetEcoImpact_FullMethodName               = "/user_service.UserService/GetEcoImpact"
+	UserService_GetPointsBalance_FullMethodName           = "/user_service.UserService/GetPointsBalance"
+	UserService_ListPointsTransactions_FullMethodName     = "/user_service.UserService/ListPointsTransactions"
+	UserService_RedeemPoints_FullMethodName               = "/user_service.UserService/RedeemPoints"
+	UserService_AdjustPoints_FullMethodName               = "/user_service.UserService/AdjustPoints"
+	UserService_GetMembership_FullMethodName              = "/user_service.UserService/GetMembership"
+	UserService_GetLeaderboard_FullMethodName             = "/user_service.UserService/GetLeaderboard"
+	UserService_GetMyRank_FullMethodName                  = "/user_service.UserService/GetMyRank"
+	UserService_SetLeaderboardOptOut_FullMethodName       = "/user_service.UserService/SetLeaderboardOptOut"
+	UserService_ListBadges_FullMethodName                 = "/user_service.UserService/ListBadges"
+	UserService_ListMyBadges_FullMethodName               = "/user_service.UserService/ListMyBadges"
+	UserService_SetGoal_FullMethodName                    = "/user_service.UserService/SetGoal"
+	UserService_GetGoalProgress_FullMethodName            = "/user_service.UserService/GetGoalProgress"
+	UserService_GetReferralStats_FullMethodName           = "/user_service.UserService/GetReferralStats"
+	UserService_GenerateEcoReport_FullMethodName          = "/user_service.UserService/GenerateEcoReport"
+	UserService_CreateSavedPlace_FullMethodName           = "/user_service.UserService/CreateSavedPlace"
+	UserService_ListSavedPlaces_FullMethodName            = "/user_service.UserService/ListSavedPlaces"
+	UserService_UpdateSavedPlace_FullMethodName           = "/user_service.UserService/UpdateSavedPlace"
+	UserService_DeleteSavedPlace_FullMethodName           = "/user_service.UserService/DeleteSavedPlace"
+	UserService_CreateEmergencyContact_FullMethodName     = "/user_service.UserService/CreateEmergencyContact"
+	UserService_ListEmergencyContacts_FullMethodName      = "/user_service.UserService/ListEmergencyContacts"
+	UserService_UpdateEmergencyContact_FullMethodName     = "/user_service.UserService/UpdateEmergencyContact"
+	UserService_DeleteEmergencyContact_FullMethodName     = "/user_service.UserService/DeleteEmergencyContact"
+	UserService_VerifyEmergencyContact_FullMethodName     = "/user_service.UserService/VerifyEmergencyContact"
+	UserService_ResendEmergencyContactCode_FullMethodName = "/user_service.UserService/ResendEmergencyContactCode"
+	UserService_GetSafetyProfile_FullMethodName           = "/user_service.UserService/GetSafetyProfile"
+	UserService_GetPreferences_FullMethodName             = "/user_service.UserService/GetPreferences"
+	UserService_UpdatePreferences_FullMethodName          = "/user_service.UserService/UpdatePreferences"
+	UserService_AuthenticateUser_FullMethodName           = "/user_service.UserService/AuthenticateUser"
+	UserService_RefreshToken_FullMethodName               = "/user_service.UserService/RefreshToken"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -39,10 +75,46 @@ type UserServiceClient interface {
 	LogIn(ctx context.Context, in *LogInRequest, opts ...grpc.CallOption) (*LogInResponse, error)
 	LogOut(ctx context.Context, in *LogOutRequest, opts ...grpc.CallOption) (*LogOutResponse, error)
 	ForgotPassword(ctx context.Context, in *ForgotPasswordRequest, opts ...grpc.CallOption) (*ForgotPasswordResponse, error)
+	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
+	RequestEmailChange(ctx context.Context, in *RequestEmailChangeRequest, opts ...grpc.CallOption) (*RequestEmailChangeResponse, error)
+	ConfirmEmailChange(ctx context.Context, in *ConfirmEmailChangeRequest, opts ...grpc.CallOption) (*ConfirmEmailChangeResponse, error)
+	RequestPhoneChange(ctx context.Context, in *RequestPhoneChangeRequest, opts ...grpc.CallOption) (*RequestPhoneChangeResponse, error)
+	ConfirmPhoneChange(ctx context.Context, in *ConfirmPhoneChangeRequest, opts ...grpc.CallOption) (*ConfirmPhoneChangeResponse, error)
+	RevertContactChange(ctx context.Context, in *RevertContactChangeRequest, opts ...grpc.CallOption) (*RevertContactChangeResponse, error)
 	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
 	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
 	UpdateDistanceTravelled(ctx context.Context, in *UpdateDistanceTravelledRequest, opts ...grpc.CallOption) (*UpdateDistanceTravelledResponse, error)
+	ListDistanceEntries(ctx context.Context, in *ListDistanceEntriesRequest, opts ...grpc.CallOption) (*ListDistanceEntriesResponse, error)
+	AdjustDistance(ctx context.Context, in *AdjustDistanceRequest, opts ...grpc.CallOption) (*AdjustDistanceResponse, error)
+	GetEcoImpact(ctx context.Context, in *GetEcoImpactRequest, opts ...grpc.CallOption) (*GetEcoImpactResponse, error)
+	GetPointsBalance(ctx context.Context, in *GetPointsBalanceRequest, opts ...grpc.CallOption) (*GetPointsBalanceResponse, error)
+	ListPointsTransactions(ctx context.Context, in *ListPointsTransactionsRequest, opts ...grpc.CallOption) (*ListPointsTransactionsResponse, error)
+	RedeemPoints(ctx context.Context, in *RedeemPointsRequest, opts ...grpc.CallOption) (*RedeemPointsResponse, error)
+	AdjustPoints(ctx context.Context, in *AdjustPointsRequest, opts ...grpc.CallOption) (*AdjustPointsResponse, error)
+	GetMembership(ctx context.Context, in *GetMembershipRequest, opts ...grpc.CallOption) (*GetMembershipResponse, error)
+	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error)
+	GetMyRank(ctx context.Context, in *GetMyRankRequest, opts ...grpc.CallOption) (*GetMyRankResponse, error)
+	SetLeaderboardOptOut(ctx context.Context, in *SetLeaderboardOptOutRequest, opts ...grpc.CallOption) (*SetLeaderboardOptOutResponse, error)
+	ListBadges(ctx context.Context, in *ListBadgesRequest, opts ...grpc.CallOption) (*ListBadgesResponse, error)
+	ListMyBadges(ctx context.Context, in *ListMyBadgesRequest, opts ...grpc.CallOption) (*ListMyBadgesResponse, error)
+	SetGoal(ctx context.Context, in *SetGoalRequest, opts ...grpc.CallOption) (*SetGoalResponse, error)
+	GetGoalProgress(ctx context.Context, in *GetGoalProgressRequest, opts ...grpc.CallOption) (*GetGoalProgressResponse, error)
+	GetReferralStats(ctx context.Context, in *GetReferralStatsRequest, opts ...grpc.CallOption) (*GetReferralStatsResponse, error)
+	GenerateEcoReport(ctx context.Context, in *GenerateEcoReportRequest, opts ...grpc.CallOption) (*GenerateEcoReportResponse, error)
+	CreateSavedPlace(ctx context.Context, in *CreateSavedPlaceRequest, opts ...grpc.CallOption) (*CreateSavedPlaceResponse, error)
+	ListSavedPlaces(ctx context.Context, in *ListSavedPlacesRequest, opts ...grpc.CallOption) (*ListSavedPlacesResponse, error)
+	UpdateSavedPlace(ctx context.Context, in *UpdateSavedPlaceRequest, opts ...grpc.CallOption) (*UpdateSavedPlaceResponse, error)
+	DeleteSavedPlace(ctx context.Context, in *DeleteSavedPlaceRequest, opts ...grpc.CallOption) (*DeleteSavedPlaceResponse, error)
+	CreateEmergencyContact(ctx context.Context, in *CreateEmergencyContactRequest, opts ...grpc.CallOption) (*CreateEmergencyContactResponse, error)
+	ListEmergencyContacts(ctx context.Context, in *ListEmergencyContactsRequest, opts ...grpc.CallOption) (*ListEmergencyContactsResponse, error)
+	UpdateEmergencyContact(ctx context.Context, in *UpdateEmergencyContactRequest, opts ...grpc.CallOption) (*UpdateEmergencyContactResponse, error)
+	DeleteEmergencyContact(ctx context.Context, in *DeleteEmergencyContactRequest, opts ...grpc.CallOption) (*DeleteEmergencyContactResponse, error)
+	VerifyEmergencyContact(ctx context.Context, in *VerifyEmergencyContactRequest, opts ...grpc.CallOption) (*VerifyEmergencyContactResponse, error)
+	ResendEmergencyContactCode(ctx context.Context, in *ResendEmergencyContactCodeRequest, opts ...grpc.CallOption) (*ResendEmergencyContactCodeResponse, error)
+	GetSafetyProfile(ctx context.Context, in *GetSafetyProfileRequest, opts ...grpc.CallOption) (*GetSafetyProfileResponse, error)
+	GetPreferences(ctx context.Context, in *GetPreferencesRequest, opts ...grpc.CallOption) (*GetPreferencesResponse, error)
+	UpdatePreferences(ctx context.Context, in *UpdatePreferencesRequest, opts ...grpc.CallOption) (*UpdatePreferencesResponse, error)
 	AuthenticateUser(ctx context.Context, in *AuthenticateUserRequest, opts ...grpc.CallOption) (*AuthenticateUserResponse, error)
 	// rpc GetToken (GetTokenRequest) returns (GetTokenResponse);
 	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
@@ -96,6 +168,16 @@ func (c *userServiceClient) ForgotPassword(ctx context.Context, in *ForgotPasswo
 	return out, nil
 }
 
+func (c *userServiceClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetPasswordResponse)
+	err := c.cc.Invoke(ctx, UserService_ResetPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateUserResponse)
@@ -106,6 +188,56 @@ func (c *userServiceClient) UpdateUser(ctx context.Context, in *UpdateUserReques
 	return out, nil
 }
 
+func (c *userServiceClient) RequestEmailChange(ctx context.Context, in *RequestEmailChangeRequest, opts ...grpc.CallOption) (*RequestEmailChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestEmailChangeResponse)
+	err := c.cc.Invoke(ctx, UserService_RequestEmailChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ConfirmEmailChange(ctx context.Context, in *ConfirmEmailChangeRequest, opts ...grpc.CallOption) (*ConfirmEmailChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmEmailChangeResponse)
+	err := c.cc.Invoke(ctx, UserService_ConfirmEmailChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RequestPhoneChange(ctx context.Context, in *RequestPhoneChangeRequest, opts ...grpc.CallOption) (*RequestPhoneChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestPhoneChangeResponse)
+	err := c.cc.Invoke(ctx, UserService_RequestPhoneChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ConfirmPhoneChange(ctx context.Context, in *ConfirmPhoneChangeRequest, opts ...grpc.CallOption) (*ConfirmPhoneChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmPhoneChangeResponse)
+	err := c.cc.Invoke(ctx, UserService_ConfirmPhoneChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevertContactChange(ctx context.Context, in *RevertContactChangeRequest, opts ...grpc.CallOption) (*RevertContactChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevertContactChangeResponse)
+	err := c.cc.Invoke(ctx, UserService_RevertContactChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetUserResponse)
@@ -116,262 +248,1354 @@ func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opt
 	return out, nil
 }
 
-func (c *userServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ChangePasswordResponse)
-	err := c.cc.Invoke(ctx, UserService_ChangePassword_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func (c *userServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangePasswordResponse)
+	err := c.cc.Invoke(ctx, UserService_ChangePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateDistanceTravelled(ctx context.Context, in *UpdateDistanceTravelledRequest, opts ...grpc.CallOption) (*UpdateDistanceTravelledResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateDistanceTravelledResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateDistanceTravelled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListDistanceEntries(ctx context.Context, in *ListDistanceEntriesRequest, opts ...grpc.CallOption) (*ListDistanceEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDistanceEntriesResponse)
+	err := c.cc.Invoke(ctx, UserService_ListDistanceEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdjustDistance(ctx context.Context, in *AdjustDistanceRequest, opts ...grpc.CallOption) (*AdjustDistanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdjustDistanceResponse)
+	err := c.cc.Invoke(ctx, UserService_AdjustDistance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetEcoImpact(ctx context.Context, in *GetEcoImpactRequest, opts ...grpc.CallOption) (*GetEcoImpactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEcoImpactResponse)
+	err := c.cc.Invoke(ctx, UserService_GetEcoImpact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetPointsBalance(ctx context.Context, in *GetPointsBalanceRequest, opts ...grpc.CallOption) (*GetPointsBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPointsBalanceResponse)
+	err := c.cc.Invoke(ctx, UserService_GetPointsBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListPointsTransactions(ctx context.Context, in *ListPointsTransactionsRequest, opts ...grpc.CallOption) (*ListPointsTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPointsTransactionsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListPointsTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RedeemPoints(ctx context.Context, in *RedeemPointsRequest, opts ...grpc.CallOption) (*RedeemPointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedeemPointsResponse)
+	err := c.cc.Invoke(ctx, UserService_RedeemPoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdjustPoints(ctx context.Context, in *AdjustPointsRequest, opts ...grpc.CallOption) (*AdjustPointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdjustPointsResponse)
+	err := c.cc.Invoke(ctx, UserService_AdjustPoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetMembership(ctx context.Context, in *GetMembershipRequest, opts ...grpc.CallOption) (*GetMembershipResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMembershipResponse)
+	err := c.cc.Invoke(ctx, UserService_GetMembership_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLeaderboardResponse)
+	err := c.cc.Invoke(ctx, UserService_GetLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetMyRank(ctx context.Context, in *GetMyRankRequest, opts ...grpc.CallOption) (*GetMyRankResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMyRankResponse)
+	err := c.cc.Invoke(ctx, UserService_GetMyRank_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetLeaderboardOptOut(ctx context.Context, in *SetLeaderboardOptOutRequest, opts ...grpc.CallOption) (*SetLeaderboardOptOutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLeaderboardOptOutResponse)
+	err := c.cc.Invoke(ctx, UserService_SetLeaderboardOptOut_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListBadges(ctx context.Context, in *ListBadgesRequest, opts ...grpc.CallOption) (*ListBadgesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBadgesResponse)
+	err := c.cc.Invoke(ctx, UserService_ListBadges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListMyBadges(ctx context.Context, in *ListMyBadgesRequest, opts ...grpc.CallOption) (*ListMyBadgesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMyBadgesResponse)
+	err := c.cc.Invoke(ctx, UserService_ListMyBadges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetGoal(ctx context.Context, in *SetGoalRequest, opts ...grpc.CallOption) (*SetGoalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetGoalResponse)
+	err := c.cc.Invoke(ctx, UserService_SetGoal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetGoalProgress(ctx context.Context, in *GetGoalProgressRequest, opts ...grpc.CallOption) (*GetGoalProgressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetGoalProgressResponse)
+	err := c.cc.Invoke(ctx, UserService_GetGoalProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetReferralStats(ctx context.Context, in *GetReferralStatsRequest, opts ...grpc.CallOption) (*GetReferralStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReferralStatsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetReferralStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GenerateEcoReport(ctx context.Context, in *GenerateEcoReportRequest, opts ...grpc.CallOption) (*GenerateEcoReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateEcoReportResponse)
+	err := c.cc.Invoke(ctx, UserService_GenerateEcoReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateSavedPlace(ctx context.Context, in *CreateSavedPlaceRequest, opts ...grpc.CallOption) (*CreateSavedPlaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSavedPlaceResponse)
+	err := c.cc.Invoke(ctx, UserService_CreateSavedPlace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListSavedPlaces(ctx context.Context, in *ListSavedPlacesRequest, opts ...grpc.CallOption) (*ListSavedPlacesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSavedPlacesResponse)
+	err := c.cc.Invoke(ctx, UserService_ListSavedPlaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateSavedPlace(ctx context.Context, in *UpdateSavedPlaceRequest, opts ...grpc.CallOption) (*UpdateSavedPlaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSavedPlaceResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateSavedPlace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteSavedPlace(ctx context.Context, in *DeleteSavedPlaceRequest, opts ...grpc.CallOption) (*DeleteSavedPlaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSavedPlaceResponse)
+	err := c.cc.Invoke(ctx, UserService_DeleteSavedPlace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateEmergencyContact(ctx context.Context, in *CreateEmergencyContactRequest, opts ...grpc.CallOption) (*CreateEmergencyContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateEmergencyContactResponse)
+	err := c.cc.Invoke(ctx, UserService_CreateEmergencyContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListEmergencyContacts(ctx context.Context, in *ListEmergencyContactsRequest, opts ...grpc.CallOption) (*ListEmergencyContactsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmergencyContactsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListEmergencyContacts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateEmergencyContact(ctx context.Context, in *UpdateEmergencyContactRequest, opts ...grpc.CallOption) (*UpdateEmergencyContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateEmergencyContactResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateEmergencyContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteEmergencyContact(ctx context.Context, in *DeleteEmergencyContactRequest, opts ...grpc.CallOption) (*DeleteEmergencyContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteEmergencyContactResponse)
+	err := c.cc.Invoke(ctx, UserService_DeleteEmergencyContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) VerifyEmergencyContact(ctx context.Context, in *VerifyEmergencyContactRequest, opts ...grpc.CallOption) (*VerifyEmergencyContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyEmergencyContactResponse)
+	err := c.cc.Invoke(ctx, UserService_VerifyEmergencyContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ResendEmergencyContactCode(ctx context.Context, in *ResendEmergencyContactCodeRequest, opts ...grpc.CallOption) (*ResendEmergencyContactCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResendEmergencyContactCodeResponse)
+	err := c.cc.Invoke(ctx, UserService_ResendEmergencyContactCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetSafetyProfile(ctx context.Context, in *GetSafetyProfileRequest, opts ...grpc.CallOption) (*GetSafetyProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSafetyProfileResponse)
+	err := c.cc.Invoke(ctx, UserService_GetSafetyProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetPreferences(ctx context.Context, in *GetPreferencesRequest, opts ...grpc.CallOption) (*GetPreferencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPreferencesResponse)
+	err := c.cc.Invoke(ctx, UserService_GetPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdatePreferences(ctx context.Context, in *UpdatePreferencesRequest, opts ...grpc.CallOption) (*UpdatePreferencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdatePreferencesResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdatePreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AuthenticateUser(ctx context.Context, in *AuthenticateUserRequest, opts ...grpc.CallOption) (*AuthenticateUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthenticateUserResponse)
+	err := c.cc.Invoke(ctx, UserService_AuthenticateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshTokenResponse)
+	err := c.cc.Invoke(ctx, UserService_RefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility.
+type UserServiceServer interface {
+	SignUp(context.Context, *SignUpRequest) (*SignUpResponse, error)
+	LogIn(context.Context, *LogInRequest) (*LogInResponse, error)
+	LogOut(context.Context, *LogOutRequest) (*LogOutResponse, error)
+	ForgotPassword(context.Context, *ForgotPasswordRequest) (*ForgotPasswordResponse, error)
+	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error)
+	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
+	RequestEmailChange(context.Context, *RequestEmailChangeRequest) (*RequestEmailChangeResponse, error)
+	ConfirmEmailChange(context.Context, *ConfirmEmailChangeRequest) (*ConfirmEmailChangeResponse, error)
+	RequestPhoneChange(context.Context, *RequestPhoneChangeRequest) (*RequestPhoneChangeResponse, error)
+	ConfirmPhoneChange(context.Context, *ConfirmPhoneChangeRequest) (*ConfirmPhoneChangeResponse, error)
+	RevertContactChange(context.Context, *RevertContactChangeRequest) (*RevertContactChangeResponse, error)
+	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
+	UpdateDistanceTravelled(context.Context, *UpdateDistanceTravelledRequest) (*UpdateDistanceTravelledResponse, error)
+	ListDistanceEntries(context.Context, *ListDistanceEntriesRequest) (*ListDistanceEntriesResponse, error)
+	AdjustDistance(context.Context, *AdjustDistanceRequest) (*AdjustDistanceResponse, error)
+	GetEcoImpact(context.Context, *GetEcoImpactRequest) (*GetEcoImpactResponse, error)
+	GetPointsBalance(context.Context, *GetPointsBalanceRequest) (*GetPointsBalanceResponse, error)
+	ListPointsTransactions(context.Context, *ListPointsTransactionsRequest) (*ListPointsTransactionsResponse, error)
+	RedeemPoints(context.Context, *RedeemPointsRequest) (*RedeemPointsResponse, error)
+	AdjustPoints(context.Context, *AdjustPointsRequest) (*AdjustPointsResponse, error)
+	GetMembership(context.Context, *GetMembershipRequest) (*GetMembershipResponse, error)
+	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error)
+	GetMyRank(context.Context, *GetMyRankRequest) (*GetMyRankResponse, error)
+	SetLeaderboardOptOut(context.Context, *SetLeaderboardOptOutRequest) (*SetLeaderboardOptOutResponse, error)
+	ListBadges(context.Context, *ListBadgesRequest) (*ListBadgesResponse, error)
+	ListMyBadges(context.Context, *ListMyBadgesRequest) (*ListMyBadgesResponse, error)
+	SetGoal(context.Context, *SetGoalRequest) (*SetGoalResponse, error)
+	GetGoalProgress(context.Context, *GetGoalProgressRequest) (*GetGoalProgressResponse, error)
+	GetReferralStats(context.Context, *GetReferralStatsRequest) (*GetReferralStatsResponse, error)
+	GenerateEcoReport(context.Context, *GenerateEcoReportRequest) (*GenerateEcoReportResponse, error)
+	CreateSavedPlace(context.Context, *CreateSavedPlaceRequest) (*CreateSavedPlaceResponse, error)
+	ListSavedPlaces(context.Context, *ListSavedPlacesRequest) (*ListSavedPlacesResponse, error)
+	UpdateSavedPlace(context.Context, *UpdateSavedPlaceRequest) (*UpdateSavedPlaceResponse, error)
+	DeleteSavedPlace(context.Context, *DeleteSavedPlaceRequest) (*DeleteSavedPlaceResponse, error)
+	CreateEmergencyContact(context.Context, *CreateEmergencyContactRequest) (*CreateEmergencyContactResponse, error)
+	ListEmergencyContacts(context.Context, *ListEmergencyContactsRequest) (*ListEmergencyContactsResponse, error)
+	UpdateEmergencyContact(context.Context, *UpdateEmergencyContactRequest) (*UpdateEmergencyContactResponse, error)
+	DeleteEmergencyContact(context.Context, *DeleteEmergencyContactRequest) (*DeleteEmergencyContactResponse, error)
+	VerifyEmergencyContact(context.Context, *VerifyEmergencyContactRequest) (*VerifyEmergencyContactResponse, error)
+	ResendEmergencyContactCode(context.Context, *ResendEmergencyContactCodeRequest) (*ResendEmergencyContactCodeResponse, error)
+	GetSafetyProfile(context.Context, *GetSafetyProfileRequest) (*GetSafetyProfileResponse, error)
+	GetPreferences(context.Context, *GetPreferencesRequest) (*GetPreferencesResponse, error)
+	UpdatePreferences(context.Context, *UpdatePreferencesRequest) (*UpdatePreferencesResponse, error)
+	AuthenticateUser(context.Context, *AuthenticateUserRequest) (*AuthenticateUserResponse, error)
+	// rpc GetToken (GetTokenRequest) returns (GetTokenResponse);
+	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserServiceServer struct{}
+
+func (UnimplementedUserServiceServer) SignUp(context.Context, *SignUpRequest) (*SignUpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignUp not implemented")
+}
+func (UnimplementedUserServiceServer) LogIn(context.Context, *LogInRequest) (*LogInResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogIn not implemented")
+}
+func (UnimplementedUserServiceServer) LogOut(context.Context, *LogOutRequest) (*LogOutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogOut not implemented")
+}
+func (UnimplementedUserServiceServer) ForgotPassword(context.Context, *ForgotPasswordRequest) (*ForgotPasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForgotPassword not implemented")
+}
+func (UnimplementedUserServiceServer) ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (UnimplementedUserServiceServer) RequestEmailChange(context.Context, *RequestEmailChangeRequest) (*RequestEmailChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestEmailChange not implemented")
+}
+func (UnimplementedUserServiceServer) ConfirmEmailChange(context.Context, *ConfirmEmailChangeRequest) (*ConfirmEmailChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmEmailChange not implemented")
+}
+func (UnimplementedUserServiceServer) RequestPhoneChange(context.Context, *RequestPhoneChangeRequest) (*RequestPhoneChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPhoneChange not implemented")
+}
+func (UnimplementedUserServiceServer) ConfirmPhoneChange(context.Context, *ConfirmPhoneChangeRequest) (*ConfirmPhoneChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmPhoneChange not implemented")
+}
+func (UnimplementedUserServiceServer) RevertContactChange(context.Context, *RevertContactChangeRequest) (*RevertContactChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertContactChange not implemented")
+}
+func (UnimplementedUserServiceServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedUserServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateDistanceTravelled(context.Context, *UpdateDistanceTravelledRequest) (*UpdateDistanceTravelledResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDistanceTravelled not implemented")
+}
+func (UnimplementedUserServiceServer) ListDistanceEntries(context.Context, *ListDistanceEntriesRequest) (*ListDistanceEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDistanceEntries not implemented")
+}
+func (UnimplementedUserServiceServer) AdjustDistance(context.Context, *AdjustDistanceRequest) (*AdjustDistanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustDistance not implemented")
+}
+func (UnimplementedUserServiceServer) GetEcoImpact(context.Context, *GetEcoImpactRequest) (*GetEcoImpactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEcoImpact not implemented")
+}
+func (UnimplementedUserServiceServer) GetPointsBalance(context.Context, *GetPointsBalanceRequest) (*GetPointsBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPointsBalance not implemented")
+}
+func (UnimplementedUserServiceServer) ListPointsTransactions(context.Context, *ListPointsTransactionsRequest) (*ListPointsTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPointsTransactions not implemented")
+}
+func (UnimplementedUserServiceServer) RedeemPoints(context.Context, *RedeemPointsRequest) (*RedeemPointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemPoints not implemented")
+}
+func (UnimplementedUserServiceServer) AdjustPoints(context.Context, *AdjustPointsRequest) (*AdjustPointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustPoints not implemented")
+}
+func (UnimplementedUserServiceServer) GetMembership(context.Context, *GetMembershipRequest) (*GetMembershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMembership not implemented")
+}
+func (UnimplementedUserServiceServer) GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (UnimplementedUserServiceServer) GetMyRank(context.Context, *GetMyRankRequest) (*GetMyRankResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMyRank not implemented")
+}
+func (UnimplementedUserServiceServer) SetLeaderboardOptOut(context.Context, *SetLeaderboardOptOutRequest) (*SetLeaderboardOptOutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLeaderboardOptOut not implemented")
+}
+func (UnimplementedUserServiceServer) ListBadges(context.Context, *ListBadgesRequest) (*ListBadgesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBadges not implemented")
+}
+func (UnimplementedUserServiceServer) ListMyBadges(context.Context, *ListMyBadgesRequest) (*ListMyBadgesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyBadges not implemented")
+}
+func (UnimplementedUserServiceServer) SetGoal(context.Context, *SetGoalRequest) (*SetGoalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetGoal not implemented")
+}
+func (UnimplementedUserServiceServer) GetGoalProgress(context.Context, *GetGoalProgressRequest) (*GetGoalProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGoalProgress not implemented")
+}
+func (UnimplementedUserServiceServer) GetReferralStats(context.Context, *GetReferralStatsRequest) (*GetReferralStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReferralStats not implemented")
+}
+func (UnimplementedUserServiceServer) GenerateEcoReport(context.Context, *GenerateEcoReportRequest) (*GenerateEcoReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateEcoReport not implemented")
+}
+func (UnimplementedUserServiceServer) CreateSavedPlace(context.Context, *CreateSavedPlaceRequest) (*CreateSavedPlaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSavedPlace not implemented")
+}
+func (UnimplementedUserServiceServer) ListSavedPlaces(context.Context, *ListSavedPlacesRequest) (*ListSavedPlacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSavedPlaces not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateSavedPlace(context.Context, *UpdateSavedPlaceRequest) (*UpdateSavedPlaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSavedPlace not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteSavedPlace(context.Context, *DeleteSavedPlaceRequest) (*DeleteSavedPlaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSavedPlace not implemented")
+}
+func (UnimplementedUserServiceServer) CreateEmergencyContact(context.Context, *CreateEmergencyContactRequest) (*CreateEmergencyContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEmergencyContact not implemented")
+}
+func (UnimplementedUserServiceServer) ListEmergencyContacts(context.Context, *ListEmergencyContactsRequest) (*ListEmergencyContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmergencyContacts not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateEmergencyContact(context.Context, *UpdateEmergencyContactRequest) (*UpdateEmergencyContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEmergencyContact not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteEmergencyContact(context.Context, *DeleteEmergencyContactRequest) (*DeleteEmergencyContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEmergencyContact not implemented")
+}
+func (UnimplementedUserServiceServer) VerifyEmergencyContact(context.Context, *VerifyEmergencyContactRequest) (*VerifyEmergencyContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmergencyContact not implemented")
+}
+func (UnimplementedUserServiceServer) ResendEmergencyContactCode(context.Context, *ResendEmergencyContactCodeRequest) (*ResendEmergencyContactCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendEmergencyContactCode not implemented")
+}
+func (UnimplementedUserServiceServer) GetSafetyProfile(context.Context, *GetSafetyProfileRequest) (*GetSafetyProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSafetyProfile not implemented")
+}
+func (UnimplementedUserServiceServer) GetPreferences(context.Context, *GetPreferencesRequest) (*GetPreferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPreferences not implemented")
+}
+func (UnimplementedUserServiceServer) UpdatePreferences(context.Context, *UpdatePreferencesRequest) (*UpdatePreferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePreferences not implemented")
+}
+func (UnimplementedUserServiceServer) AuthenticateUser(context.Context, *AuthenticateUserRequest) (*AuthenticateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthenticateUser not implemented")
+}
+func (UnimplementedUserServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUserServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_SignUp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignUpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SignUp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SignUp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SignUp(ctx, req.(*SignUpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_LogIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).LogIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_LogIn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).LogIn(ctx, req.(*LogInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_LogOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogOutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).LogOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_LogOut_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).LogOut(ctx, req.(*LogOutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ForgotPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForgotPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ForgotPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ForgotPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ForgotPassword(ctx, req.(*ForgotPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ResetPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RequestEmailChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEmailChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RequestEmailChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RequestEmailChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RequestEmailChange(ctx, req.(*RequestEmailChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ConfirmEmailChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmEmailChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ConfirmEmailChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ConfirmEmailChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ConfirmEmailChange(ctx, req.(*ConfirmEmailChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RequestPhoneChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPhoneChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RequestPhoneChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RequestPhoneChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RequestPhoneChange(ctx, req.(*RequestPhoneChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ConfirmPhoneChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmPhoneChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ConfirmPhoneChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ConfirmPhoneChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ConfirmPhoneChange(ctx, req.(*ConfirmPhoneChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevertContactChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertContactChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevertContactChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RevertContactChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevertContactChange(ctx, req.(*RevertContactChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateDistanceTravelled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDistanceTravelledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateDistanceTravelled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateDistanceTravelled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateDistanceTravelled(ctx, req.(*UpdateDistanceTravelledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListDistanceEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDistanceEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListDistanceEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListDistanceEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListDistanceEntries(ctx, req.(*ListDistanceEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdjustDistance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustDistanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdjustDistance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdjustDistance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdjustDistance(ctx, req.(*AdjustDistanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetEcoImpact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEcoImpactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetEcoImpact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetEcoImpact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetEcoImpact(ctx, req.(*GetEcoImpactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetPointsBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPointsBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetPointsBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetPointsBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetPointsBalance(ctx, req.(*GetPointsBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListPointsTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPointsTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListPointsTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListPointsTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListPointsTransactions(ctx, req.(*ListPointsTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RedeemPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemPointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RedeemPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RedeemPoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RedeemPoints(ctx, req.(*RedeemPointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdjustPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustPointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdjustPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdjustPoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdjustPoints(ctx, req.(*AdjustPointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetMembership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMembershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetMembership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetMembership_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetMembership(ctx, req.(*GetMembershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetLeaderboard(ctx, req.(*GetLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetMyRank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMyRankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetMyRank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetMyRank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetMyRank(ctx, req.(*GetMyRankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetLeaderboardOptOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLeaderboardOptOutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetLeaderboardOptOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetLeaderboardOptOut_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetLeaderboardOptOut(ctx, req.(*SetLeaderboardOptOutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListBadges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBadgesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListBadges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListBadges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListBadges(ctx, req.(*ListBadgesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListMyBadges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyBadgesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListMyBadges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListMyBadges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListMyBadges(ctx, req.(*ListMyBadgesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetGoal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetGoalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetGoal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetGoal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetGoal(ctx, req.(*SetGoalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetGoalProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGoalProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetGoalProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetGoalProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetGoalProgress(ctx, req.(*GetGoalProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetReferralStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReferralStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetReferralStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetReferralStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetReferralStats(ctx, req.(*GetReferralStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GenerateEcoReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateEcoReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GenerateEcoReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GenerateEcoReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GenerateEcoReport(ctx, req.(*GenerateEcoReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateSavedPlace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSavedPlaceRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateSavedPlace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateSavedPlace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateSavedPlace(ctx, req.(*CreateSavedPlaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *userServiceClient) UpdateDistanceTravelled(ctx context.Context, in *UpdateDistanceTravelledRequest, opts ...grpc.CallOption) (*UpdateDistanceTravelledResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(UpdateDistanceTravelledResponse)
-	err := c.cc.Invoke(ctx, UserService_UpdateDistanceTravelled_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _UserService_ListSavedPlaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSavedPlacesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListSavedPlaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListSavedPlaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListSavedPlaces(ctx, req.(*ListSavedPlacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *userServiceClient) AuthenticateUser(ctx context.Context, in *AuthenticateUserRequest, opts ...grpc.CallOption) (*AuthenticateUserResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(AuthenticateUserResponse)
-	err := c.cc.Invoke(ctx, UserService_AuthenticateUser_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _UserService_UpdateSavedPlace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSavedPlaceRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateSavedPlace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateSavedPlace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateSavedPlace(ctx, req.(*UpdateSavedPlaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *userServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(RefreshTokenResponse)
-	err := c.cc.Invoke(ctx, UserService_RefreshToken_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _UserService_DeleteSavedPlace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSavedPlaceRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// UserServiceServer is the server API for UserService service.
-// All implementations must embed UnimplementedUserServiceServer
-// for forward compatibility.
-type UserServiceServer interface {
-	SignUp(context.Context, *SignUpRequest) (*SignUpResponse, error)
-	LogIn(context.Context, *LogInRequest) (*LogInResponse, error)
-	LogOut(context.Context, *LogOutRequest) (*LogOutResponse, error)
-	ForgotPassword(context.Context, *ForgotPasswordRequest) (*ForgotPasswordResponse, error)
-	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
-	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
-	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
-	UpdateDistanceTravelled(context.Context, *UpdateDistanceTravelledRequest) (*UpdateDistanceTravelledResponse, error)
-	AuthenticateUser(context.Context, *AuthenticateUserRequest) (*AuthenticateUserResponse, error)
-	// rpc GetToken (GetTokenRequest) returns (GetTokenResponse);
-	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
-	mustEmbedUnimplementedUserServiceServer()
-}
-
-// UnimplementedUserServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedUserServiceServer struct{}
-
-func (UnimplementedUserServiceServer) SignUp(context.Context, *SignUpRequest) (*SignUpResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SignUp not implemented")
-}
-func (UnimplementedUserServiceServer) LogIn(context.Context, *LogInRequest) (*LogInResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LogIn not implemented")
-}
-func (UnimplementedUserServiceServer) LogOut(context.Context, *LogOutRequest) (*LogOutResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LogOut not implemented")
-}
-func (UnimplementedUserServiceServer) ForgotPassword(context.Context, *ForgotPasswordRequest) (*ForgotPasswordResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ForgotPassword not implemented")
-}
-func (UnimplementedUserServiceServer) UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
-}
-func (UnimplementedUserServiceServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
-}
-func (UnimplementedUserServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
-}
-func (UnimplementedUserServiceServer) UpdateDistanceTravelled(context.Context, *UpdateDistanceTravelledRequest) (*UpdateDistanceTravelledResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateDistanceTravelled not implemented")
-}
-func (UnimplementedUserServiceServer) AuthenticateUser(context.Context, *AuthenticateUserRequest) (*AuthenticateUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AuthenticateUser not implemented")
-}
-func (UnimplementedUserServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
-}
-func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
-func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
-
-// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to UserServiceServer will
-// result in compilation errors.
-type UnsafeUserServiceServer interface {
-	mustEmbedUnimplementedUserServiceServer()
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteSavedPlace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteSavedPlace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteSavedPlace(ctx, req.(*DeleteSavedPlaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
-	// If the following call pancis, it indicates UnimplementedUserServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _UserService_CreateEmergencyContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEmergencyContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&UserService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateEmergencyContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateEmergencyContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateEmergencyContact(ctx, req.(*CreateEmergencyContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_SignUp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SignUpRequest)
+func _UserService_ListEmergencyContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmergencyContactsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).SignUp(ctx, in)
+		return srv.(UserServiceServer).ListEmergencyContacts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_SignUp_FullMethodName,
+		FullMethod: UserService_ListEmergencyContacts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).SignUp(ctx, req.(*SignUpRequest))
+		return srv.(UserServiceServer).ListEmergencyContacts(ctx, req.(*ListEmergencyContactsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_LogIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LogInRequest)
+func _UserService_UpdateEmergencyContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEmergencyContactRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).LogIn(ctx, in)
+		return srv.(UserServiceServer).UpdateEmergencyContact(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_LogIn_FullMethodName,
+		FullMethod: UserService_UpdateEmergencyContact_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).LogIn(ctx, req.(*LogInRequest))
+		return srv.(UserServiceServer).UpdateEmergencyContact(ctx, req.(*UpdateEmergencyContactRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_LogOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LogOutRequest)
+func _UserService_DeleteEmergencyContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEmergencyContactRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).LogOut(ctx, in)
+		return srv.(UserServiceServer).DeleteEmergencyContact(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_LogOut_FullMethodName,
+		FullMethod: UserService_DeleteEmergencyContact_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).LogOut(ctx, req.(*LogOutRequest))
+		return srv.(UserServiceServer).DeleteEmergencyContact(ctx, req.(*DeleteEmergencyContactRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_ForgotPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ForgotPasswordRequest)
+func _UserService_VerifyEmergencyContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmergencyContactRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).ForgotPassword(ctx, in)
+		return srv.(UserServiceServer).VerifyEmergencyContact(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_ForgotPassword_FullMethodName,
+		FullMethod: UserService_VerifyEmergencyContact_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).ForgotPassword(ctx, req.(*ForgotPasswordRequest))
+		return srv.(UserServiceServer).VerifyEmergencyContact(ctx, req.(*VerifyEmergencyContactRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateUserRequest)
+func _UserService_ResendEmergencyContactCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendEmergencyContactCodeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).UpdateUser(ctx, in)
+		return srv.(UserServiceServer).ResendEmergencyContactCode(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_UpdateUser_FullMethodName,
+		FullMethod: UserService_ResendEmergencyContactCode_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+		return srv.(UserServiceServer).ResendEmergencyContactCode(ctx, req.(*ResendEmergencyContactCodeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserRequest)
+func _UserService_GetSafetyProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSafetyProfileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).GetUser(ctx, in)
+		return srv.(UserServiceServer).GetSafetyProfile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_GetUser_FullMethodName,
+		FullMethod: UserService_GetSafetyProfile_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).GetUser(ctx, req.(*GetUserRequest))
+		return srv.(UserServiceServer).GetSafetyProfile(ctx, req.(*GetSafetyProfileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChangePasswordRequest)
+func _UserService_GetPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPreferencesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).ChangePassword(ctx, in)
+		return srv.(UserServiceServer).GetPreferences(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_ChangePassword_FullMethodName,
+		FullMethod: UserService_GetPreferences_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+		return srv.(UserServiceServer).GetPreferences(ctx, req.(*GetPreferencesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_UpdateDistanceTravelled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateDistanceTravelledRequest)
+func _UserService_UpdatePreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePreferencesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).UpdateDistanceTravelled(ctx, in)
+		return srv.(UserServiceServer).UpdatePreferences(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_UpdateDistanceTravelled_FullMethodName,
+		FullMethod: UserService_UpdatePreferences_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).UpdateDistanceTravelled(ctx, req.(*UpdateDistanceTravelledRequest))
+		return srv.(UserServiceServer).UpdatePreferences(ctx, req.(*UpdatePreferencesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -435,10 +1659,34 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ForgotPassword",
 			Handler:    _UserService_ForgotPassword_Handler,
 		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _UserService_ResetPassword_Handler,
+		},
 		{
 			MethodName: "UpdateUser",
 			Handler:    _UserService_UpdateUser_Handler,
 		},
+		{
+			MethodName: "RequestEmailChange",
+			Handler:    _UserService_RequestEmailChange_Handler,
+		},
+		{
+			MethodName: "ConfirmEmailChange",
+			Handler:    _UserService_ConfirmEmailChange_Handler,
+		},
+		{
+			MethodName: "RequestPhoneChange",
+			Handler:    _UserService_RequestPhoneChange_Handler,
+		},
+		{
+			MethodName: "ConfirmPhoneChange",
+			Handler:    _UserService_ConfirmPhoneChange_Handler,
+		},
+		{
+			MethodName: "RevertContactChange",
+			Handler:    _UserService_RevertContactChange_Handler,
+		},
 		{
 			MethodName: "GetUser",
 			Handler:    _UserService_GetUser_Handler,
@@ -451,6 +1699,126 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateDistanceTravelled",
 			Handler:    _UserService_UpdateDistanceTravelled_Handler,
 		},
+		{
+			MethodName: "ListDistanceEntries",
+			Handler:    _UserService_ListDistanceEntries_Handler,
+		},
+		{
+			MethodName: "AdjustDistance",
+			Handler:    _UserService_AdjustDistance_Handler,
+		},
+		{
+			MethodName: "GetEcoImpact",
+			Handler:    _UserService_GetEcoImpact_Handler,
+		},
+		{
+			MethodName: "GetPointsBalance",
+			Handler:    _UserService_GetPointsBalance_Handler,
+		},
+		{
+			MethodName: "ListPointsTransactions",
+			Handler:    _UserService_ListPointsTransactions_Handler,
+		},
+		{
+			MethodName: "RedeemPoints",
+			Handler:    _UserService_RedeemPoints_Handler,
+		},
+		{
+			MethodName: "AdjustPoints",
+			Handler:    _UserService_AdjustPoints_Handler,
+		},
+		{
+			MethodName: "GetMembership",
+			Handler:    _UserService_GetMembership_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _UserService_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "GetMyRank",
+			Handler:    _UserService_GetMyRank_Handler,
+		},
+		{
+			MethodName: "SetLeaderboardOptOut",
+			Handler:    _UserService_SetLeaderboardOptOut_Handler,
+		},
+		{
+			MethodName: "ListBadges",
+			Handler:    _UserService_ListBadges_Handler,
+		},
+		{
+			MethodName: "ListMyBadges",
+			Handler:    _UserService_ListMyBadges_Handler,
+		},
+		{
+			MethodName: "SetGoal",
+			Handler:    _UserService_SetGoal_Handler,
+		},
+		{
+			MethodName: "GetGoalProgress",
+			Handler:    _UserService_GetGoalProgress_Handler,
+		},
+		{
+			MethodName: "GetReferralStats",
+			Handler:    _UserService_GetReferralStats_Handler,
+		},
+		{
+			MethodName: "GenerateEcoReport",
+			Handler:    _UserService_GenerateEcoReport_Handler,
+		},
+		{
+			MethodName: "CreateSavedPlace",
+			Handler:    _UserService_CreateSavedPlace_Handler,
+		},
+		{
+			MethodName: "ListSavedPlaces",
+			Handler:    _UserService_ListSavedPlaces_Handler,
+		},
+		{
+			MethodName: "UpdateSavedPlace",
+			Handler:    _UserService_UpdateSavedPlace_Handler,
+		},
+		{
+			MethodName: "DeleteSavedPlace",
+			Handler:    _UserService_DeleteSavedPlace_Handler,
+		},
+		{
+			MethodName: "CreateEmergencyContact",
+			Handler:    _UserService_CreateEmergencyContact_Handler,
+		},
+		{
+			MethodName: "ListEmergencyContacts",
+			Handler:    _UserService_ListEmergencyContacts_Handler,
+		},
+		{
+			MethodName: "UpdateEmergencyContact",
+			Handler:    _UserService_UpdateEmergencyContact_Handler,
+		},
+		{
+			MethodName: "DeleteEmergencyContact",
+			Handler:    _UserService_DeleteEmergencyContact_Handler,
+		},
+		{
+			MethodName: "VerifyEmergencyContact",
+			Handler:    _UserService_VerifyEmergencyContact_Handler,
+		},
+		{
+			MethodName: "ResendEmergencyContactCode",
+			Handler:    _UserService_ResendEmergencyContactCode_Handler,
+		},
+		{
+			MethodName: "GetSafetyProfile",
+			Handler:    _UserService_GetSafetyProfile_Handler,
+		},
+		{
+			MethodName: "GetPreferences",
+			Handler:    _UserService_GetPreferences_Handler,
+		},
+		{
+			MethodName: "UpdatePreferences",
+			Handler:    _UserService_UpdatePreferences_Handler,
+		},
 		{
 			MethodName: "AuthenticateUser",
 			Handler:    _UserService_AuthenticateUser_Handler,
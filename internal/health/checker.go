@@ -0,0 +1,188 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Name under which the overall User Service status is reported, next to the
+// empty service name and one entry per dependency
+const ServiceName = "user_service.UserService"
+
+type dependency struct {
+	name  string
+	probe func(ctx context.Context) error
+}
+
+// Checker periodically probes the service dependencies and publishes the
+// result through the standard grpc.health.v1 Health service and Ready.
+type Checker struct {
+	server   *health.Server
+	deps     []dependency
+	interval time.Duration
+	timeout  time.Duration
+
+	mu           sync.RWMutex
+	statuses     map[string]healthpb.HealthCheckResponse_ServingStatus
+	shuttingDown bool
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewChecker(cfg config.HealthConfig, smtp config.SMTPConfig) *Checker {
+	c := &Checker{
+		server:   health.NewServer(),
+		interval: cfg.CheckInterval,
+		timeout:  cfg.CheckTimeout,
+		statuses: make(map[string]healthpb.HealthCheckResponse_ServingStatus),
+		stop:     make(chan struct{}),
+	}
+
+	c.deps = []dependency{
+		{name: "mysql", probe: pingMySQL},
+		{name: "redis", probe: pingRedis},
+	}
+	if cfg.CheckSMTP {
+		c.deps = append(c.deps, dependency{name: "smtp", probe: dialSMTP(smtp)})
+	}
+
+	// Nothing is ready until the first round of checks has passed
+	for _, dep := range c.deps {
+		c.statuses[dep.name] = healthpb.HealthCheckResponse_NOT_SERVING
+		c.server.SetServingStatus(dep.name, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	c.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	c.server.SetServingStatus(ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return c
+}
+
+// Returns the grpc.health.v1 implementation to register on the gRPC server
+func (c *Checker) HealthServer() healthpb.HealthServer {
+	return c.server
+}
+
+func (c *Checker) Name() string {
+	return "health checker"
+}
+
+// Probes the dependencies every interval until Shutdown
+func (c *Checker) Serve() error {
+	c.checkAll()
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.checkAll()
+		case <-c.stop:
+			return nil
+		}
+	}
+}
+
+func (c *Checker) Shutdown(ctx context.Context) error {
+	c.SetNotServing()
+	c.stopOnce.Do(func() { close(c.stop) })
+	return nil
+}
+
+// Reports NOT_SERVING everywhere from now on, so load balancers stop sending
+// traffic while in-flight requests drain
+func (c *Checker) SetNotServing() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.shuttingDown = true
+	c.server.Shutdown()
+}
+
+// Reports whether the service can take traffic, with the status of each dependency
+func (c *Checker) Ready() (bool, map[string]string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ready := !c.shuttingDown
+	details := make(map[string]string, len(c.statuses))
+	for name, status := range c.statuses {
+		details[name] = status.String()
+		if status != healthpb.HealthCheckResponse_SERVING {
+			ready = false
+		}
+	}
+	return ready, details
+}
+
+func (c *Checker) checkAll() {
+	overall := healthpb.HealthCheckResponse_SERVING
+	results := make(map[string]healthpb.HealthCheckResponse_ServingStatus, len(c.deps))
+
+	for _, dep := range c.deps {
+		ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+		err := dep.probe(ctx)
+		cancel()
+
+		status := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			log.Printf("Health check %s failed: %v", dep.name, err)
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			overall = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		results[dep.name] = status
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.shuttingDown {
+		return
+	}
+	for name, status := range results {
+		c.statuses[name] = status
+		c.server.SetServingStatus(name, status)
+	}
+	c.server.SetServingStatus("", overall)
+	c.server.SetServingStatus(ServiceName, overall)
+}
+
+func pingMySQL(ctx context.Context) error {
+	if config.DB == nil {
+		return errors.New("MySQL is not connected")
+	}
+
+	sqlDB, err := config.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func pingRedis(ctx context.Context) error {
+	if config.Redis == nil {
+		return errors.New("Redis is not connected")
+	}
+	return config.Redis.Ping(ctx).Err()
+}
+
+func dialSMTP(smtp config.SMTPConfig) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(smtp.Host, strconv.Itoa(smtp.Port)))
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
@@ -0,0 +1,68 @@
+package job
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/leaderboard"
+)
+
+// LeaderboardRebuild checks on a timer that the leaderboards are still in
+// Redis and rebuilds them from the distance ledger when they are not
+type LeaderboardRebuild struct {
+	interval time.Duration
+
+	// Cancels the rebuild in progress on shutdown
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewLeaderboardRebuild(cfg config.LeaderboardConfig) *LeaderboardRebuild {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &LeaderboardRebuild{
+		interval: cfg.CheckInterval,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+}
+
+func (j *LeaderboardRebuild) Name() string {
+	return "leaderboard rebuild"
+}
+
+// Checks the leaderboards every interval until Shutdown
+func (j *LeaderboardRebuild) Serve() error {
+	defer close(j.done)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := leaderboard.EnsureBuilt(j.ctx, time.Now()); err != nil && j.ctx.Err() == nil {
+			log.Println("Failed to rebuild leaderboards:", err.Error())
+		}
+
+		select {
+		case <-ticker.C:
+		case <-j.ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (j *LeaderboardRebuild) Shutdown(ctx context.Context) error {
+	j.once.Do(j.cancel)
+
+	select {
+	case <-j.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
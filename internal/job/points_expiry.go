@@ -0,0 +1,94 @@
+// Package job holds the background work the service runs next to its servers.
+package job
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+)
+
+// PointsExpiry expires points older than the configured window on a timer.
+// Every replica runs it; each grant is locked while expired so none is
+// expired twice.
+type PointsExpiry struct {
+	interval  time.Duration
+	batchSize int
+
+	// Cancels the pass in progress on shutdown
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewPointsExpiry(cfg config.PointsConfig) *PointsExpiry {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &PointsExpiry{
+		interval:  cfg.ExpiryInterval,
+		batchSize: cfg.ExpiryBatchSize,
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+}
+
+func (j *PointsExpiry) Name() string {
+	return "points expiry"
+}
+
+// Expires points every interval until Shutdown
+func (j *PointsExpiry) Serve() error {
+	defer close(j.done)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.expire()
+
+		select {
+		case <-ticker.C:
+		case <-j.ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (j *PointsExpiry) Shutdown(ctx context.Context) error {
+	j.once.Do(j.cancel)
+
+	select {
+	case <-j.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Expires batches until none is full, so a backlog is cleared in one pass
+func (j *PointsExpiry) expire() {
+	pointsRepo := repository.NewPointsRepo(config.DB)
+	total := 0
+
+	for j.ctx.Err() == nil {
+		expired, err := pointsRepo.ExpireGrants(j.ctx, time.Now(), j.batchSize)
+		total += expired
+		if err != nil {
+			if j.ctx.Err() == nil {
+				log.Println("Failed to expire points:", err.Error())
+			}
+			break
+		}
+		if expired < j.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		log.Printf("Expired points of %d grants", total)
+	}
+}
@@ -0,0 +1,134 @@
+package job
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/cache"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/email"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/streak"
+)
+
+// StreakReminder emails users whose daily streak ends at midnight, their
+// time, unless they take a trip today. Each user is reminded at most once a
+// day, in their language, unless they turned streak reminders off.
+type StreakReminder struct {
+	cfg config.GoalsConfig
+
+	// Cancels the pass in progress on shutdown
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewStreakReminder(cfg config.GoalsConfig) *StreakReminder {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &StreakReminder{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+}
+
+func (j *StreakReminder) Name() string {
+	return "streak reminder"
+}
+
+// Reminds users every interval until Shutdown
+func (j *StreakReminder) Serve() error {
+	defer close(j.done)
+
+	ticker := time.NewTicker(j.cfg.ReminderInterval)
+	defer ticker.Stop()
+
+	for {
+		j.remind()
+
+		select {
+		case <-ticker.C:
+		case <-j.ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (j *StreakReminder) Shutdown(ctx context.Context) error {
+	j.once.Do(j.cancel)
+
+	select {
+	case <-j.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Walks in batches the users who took a trip in the last two days, the only
+// ones whose streak can still be saved today in any time zone
+func (j *StreakReminder) remind() {
+	distanceRepo := repository.NewDistanceRepo(config.DB)
+	now := time.Now()
+	var afterUserId uint64
+
+	for j.ctx.Err() == nil {
+		ids, err := distanceRepo.ListTravellers(j.ctx, now.Add(-48*time.Hour), afterUserId, j.cfg.ReminderBatchSize)
+		if err != nil {
+			if j.ctx.Err() == nil {
+				log.Println("Failed to list users to remind:", err.Error())
+			}
+			return
+		}
+
+		for _, id := range ids {
+			if err := j.remindUser(id, now); err != nil && j.ctx.Err() == nil {
+				log.Printf("Failed to remind user %d of their streak: %v", id, err)
+			}
+		}
+
+		if len(ids) < j.cfg.ReminderBatchSize {
+			return
+		}
+		afterUserId = ids[len(ids)-1]
+	}
+}
+
+func (j *StreakReminder) remindUser(userId uint64, now time.Time) error {
+	user := model.User{Id: userId}
+	if err := repository.NewUserRepo(config.DB).GetUser(j.ctx, &user); err != nil {
+		return err
+	}
+
+	local := now.In(user.Location())
+	if local.Hour() < j.cfg.ReminderHour {
+		return nil
+	}
+
+	preferences, err := repository.NewPreferencesRepo(config.DB).Get(j.ctx, userId)
+	if err != nil || !preferences.StreakReminders {
+		return err
+	}
+
+	s, err := streak.Load(j.ctx, &user, now, j.cfg.StreakLookback)
+	if err != nil {
+		return err
+	}
+	if !s.AtRisk() || s.Daily < j.cfg.ReminderMinStreak {
+		return nil
+	}
+
+	// Marked before sending: a failed email is not retried rather than sent twice
+	first, err := cache.NewReminderCache(config.Redis).MarkStreakReminded(j.ctx, userId, local.Format("2006-01-02"))
+	if err != nil || !first {
+		return err
+	}
+
+	data := map[string]interface{}{"Days": s.Daily, "Link": config.AppConfig.FrontendURL}
+	return email.Send(user.Email, preferences.Language, email.StreakReminder, data)
+}
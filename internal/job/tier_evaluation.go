@@ -0,0 +1,96 @@
+package job
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/membership"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+)
+
+// TierEvaluation re-evaluates members above the first tier on a timer, so
+// tiers drop as old trips leave the rolling window even without new trips
+type TierEvaluation struct {
+	interval  time.Duration
+	batchSize int
+
+	// Cancels the pass in progress on shutdown
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewTierEvaluation(cfg config.TierConfig) *TierEvaluation {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &TierEvaluation{
+		interval:  cfg.EvaluationInterval,
+		batchSize: cfg.EvaluationBatchSize,
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+}
+
+func (j *TierEvaluation) Name() string {
+	return "tier evaluation"
+}
+
+// Evaluates members every interval until Shutdown
+func (j *TierEvaluation) Serve() error {
+	defer close(j.done)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.evaluate()
+
+		select {
+		case <-ticker.C:
+		case <-j.ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (j *TierEvaluation) Shutdown(ctx context.Context) error {
+	j.once.Do(j.cancel)
+
+	select {
+	case <-j.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Walks every member above the first tier in batches
+func (j *TierEvaluation) evaluate() {
+	membershipRepo := repository.NewMembershipRepo(config.DB)
+	var afterUserId uint64
+
+	for j.ctx.Err() == nil {
+		ids, err := membershipRepo.ListForReview(j.ctx, afterUserId, j.batchSize)
+		if err != nil {
+			if j.ctx.Err() == nil {
+				log.Println("Failed to list members to evaluate:", err.Error())
+			}
+			return
+		}
+
+		for _, id := range ids {
+			if _, err := membership.Evaluate(j.ctx, id, time.Now()); err != nil && j.ctx.Err() == nil {
+				log.Printf("Failed to evaluate membership of user %d: %v", id, err)
+			}
+		}
+
+		if len(ids) < j.batchSize {
+			return
+		}
+		afterUserId = ids[len(ids)-1]
+	}
+}
@@ -0,0 +1,203 @@
+// Package leaderboard ranks users by CO2 saved on weekly, monthly and
+// all-time boards, across every user and within each city, kept in Redis
+// sorted sets which can always be rebuilt from the distance ledger.
+package leaderboard
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/cache"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+)
+
+// Record adds the CO2 saved by a ledger entry to the boards of its periods,
+// unless the user opted out. Adjustments are counted like trips, negative
+// ones taking CO2 back.
+func Record(ctx context.Context, entry *model.DistanceEntry) error {
+	user := model.User{Id: entry.UserId}
+	if err := repository.NewUserRepo(config.DB).GetUser(ctx, &user); err != nil {
+		return err
+	}
+	if user.LeaderboardOptOut {
+		return nil
+	}
+
+	saved := entry.Distance * config.AppConfig.Emissions.SavedPerKm(entry.Kind, entry.VehicleType)
+	if saved == 0 {
+		return nil
+	}
+
+	return cache.NewLeaderboardCache(config.Redis).Add(ctx, entry.UserId, CityKey(user.City), saved, entry.CreatedAt)
+}
+
+// Remove takes the user off the boards of the current periods, the global
+// ones and those of their city
+func Remove(ctx context.Context, userId uint64, city string, now time.Time) error {
+	return cache.NewLeaderboardCache(config.Redis).Remove(ctx, userId, CityKey(city), now)
+}
+
+// Move ranks the user on the boards of their new city instead of the old one
+func Move(ctx context.Context, userId uint64, oldCity, newCity string, now time.Time) error {
+	if err := Remove(ctx, userId, oldCity, now); err != nil {
+		return err
+	}
+	return Restore(ctx, userId, newCity, now)
+}
+
+// Restore puts the user back on the boards of the current periods, the
+// global ones and those of their city, with the CO2 they saved according to
+// the ledger
+func Restore(ctx context.Context, userId uint64, city string, now time.Time) error {
+	distanceRepo := repository.NewDistanceRepo(config.DB)
+	leaderboardCache := cache.NewLeaderboardCache(config.Redis)
+
+	for _, board := range cache.Boards {
+		sums, err := distanceRepo.SumByUser(ctx, userId, PeriodStart(board, now))
+		if err != nil {
+			return err
+		}
+
+		saved, ok := savedByUser(sums)[userId]
+		if !ok {
+			continue
+		}
+		if err := leaderboardCache.Set(ctx, board, "", now, userId, saved); err != nil {
+			return err
+		}
+		if cityKey := CityKey(city); cityKey != "" {
+			if err := leaderboardCache.Set(ctx, board, cityKey, now, userId, saved); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// EnsureBuilt rebuilds the boards from the ledger when Redis lost them, e.g.
+// after a flush
+func EnsureBuilt(ctx context.Context, now time.Time) error {
+	leaderboardCache := cache.NewLeaderboardCache(config.Redis)
+
+	built, err := leaderboardCache.IsBuilt(ctx)
+	if err != nil || built {
+		return err
+	}
+
+	log.Println("Leaderboards are missing from Redis, rebuilding them from the distance ledger")
+	if err := Rebuild(ctx, now); err != nil {
+		return err
+	}
+	return leaderboardCache.MarkBuilt(ctx)
+}
+
+// Rebuild replaces the boards of the current periods with sums from the
+// ledger. Trips recorded while it runs may be missed until the next rebuild.
+func Rebuild(ctx context.Context, now time.Time) error {
+	distanceRepo := repository.NewDistanceRepo(config.DB)
+	leaderboardCache := cache.NewLeaderboardCache(config.Redis)
+
+	for _, board := range cache.Boards {
+		sums, err := distanceRepo.SumByUser(ctx, 0, PeriodStart(board, now))
+		if err != nil {
+			return err
+		}
+
+		saved := savedByUser(sums)
+		if err := leaderboardCache.Replace(ctx, board, "", now, saved); err != nil {
+			return err
+		}
+		for city, scores := range savedByCity(sums, saved) {
+			if err := leaderboardCache.Replace(ctx, board, city, now, scores); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// PeriodStart returns the start of the board's period containing now: Monday
+// for weekly boards, the first of the month for monthly ones and nil for the
+// all-time board
+func PeriodStart(board string, now time.Time) *time.Time {
+	year, month, day := now.Date()
+	var start time.Time
+
+	switch board {
+	case cache.BoardWeekly:
+		// Weeks start on Monday, like the ISO weeks the boards are keyed by
+		offset := (int(now.Weekday()) + 6) % 7
+		start = time.Date(year, month, day-offset, 0, 0, 0, 0, now.Location())
+	case cache.BoardMonthly:
+		start = time.Date(year, month, 1, 0, 0, 0, 0, now.Location())
+	default:
+		return nil
+	}
+
+	return &start
+}
+
+// Turns distance per user and vehicle type into grams of CO2 saved per user
+func savedByUser(sums []model.DistanceByUser) map[uint64]float64 {
+	emissions := config.AppConfig.Emissions
+	saved := make(map[uint64]float64)
+
+	for _, sum := range sums {
+		saved[sum.UserId] += sum.Distance * emissions.SavedPerKm(sum.Kind, sum.VehicleType)
+	}
+	for userId, grams := range saved {
+		if grams <= 0 {
+			delete(saved, userId)
+		}
+	}
+
+	return saved
+}
+
+// Splits CO2 saved per user by the key of the users' city, leaving out users
+// without one
+func savedByCity(sums []model.DistanceByUser, saved map[uint64]float64) map[string]map[uint64]float64 {
+	cities := make(map[string]map[uint64]float64)
+
+	for _, sum := range sums {
+		city := CityKey(sum.City)
+		grams, ok := saved[sum.UserId]
+		if city == "" || !ok {
+			continue
+		}
+		if cities[city] == nil {
+			cities[city] = make(map[uint64]float64)
+		}
+		cities[city][sum.UserId] = grams
+	}
+
+	return cities
+}
+
+// CityKey returns the form of a city boards are keyed by, so "Ho Chi Minh
+// City" and "ho chi minh city " share one
+func CityKey(city string) string {
+	return strings.Join(strings.Fields(strings.ToLower(city)), "-")
+}
+
+// DisplayName returns how a user appears on the boards, their first name and
+// the initial of their last one, e.g. "Hai Y." for "Hai Yen"
+func DisplayName(name string) string {
+	words := strings.Fields(name)
+	switch len(words) {
+	case 0:
+		return ""
+	case 1:
+		return words[0]
+	}
+
+	initial, _ := utf8.DecodeRuneInString(words[len(words)-1])
+	return words[0] + " " + string(initial) + "."
+}
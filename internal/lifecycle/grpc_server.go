@@ -0,0 +1,46 @@
+package lifecycle
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+)
+
+type grpcServer struct {
+	server *grpc.Server
+	lis    net.Listener
+}
+
+// Wraps a gRPC server bound to an already opened listener
+func NewGRPCServer(server *grpc.Server, lis net.Listener) Server {
+	return &grpcServer{
+		server: server,
+		lis:    lis,
+	}
+}
+
+func (s *grpcServer) Name() string {
+	return "gRPC server on " + s.lis.Addr().String()
+}
+
+func (s *grpcServer) Serve() error {
+	return s.server.Serve(s.lis)
+}
+
+// Waits for in-flight RPCs to finish, cancelling them once ctx is done
+func (s *grpcServer) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,41 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+)
+
+type httpServer struct {
+	server *http.Server
+	lis    net.Listener
+}
+
+// Wraps an HTTP server bound to an already opened listener
+func NewHTTPServer(server *http.Server, lis net.Listener) Server {
+	return &httpServer{
+		server: server,
+		lis:    lis,
+	}
+}
+
+func (s *httpServer) Name() string {
+	return "HTTP server on " + s.lis.Addr().String()
+}
+
+func (s *httpServer) Serve() error {
+	if err := s.server.Serve(s.lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Stops accepting connections and waits for in-flight requests until ctx is done
+func (s *httpServer) Shutdown(ctx context.Context) error {
+	if err := s.server.Shutdown(ctx); err != nil {
+		_ = s.server.Close()
+		return err
+	}
+	return nil
+}
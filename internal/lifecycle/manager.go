@@ -0,0 +1,145 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Server is a long running component managed by the Manager. Serve blocks
+// until the server stops and Shutdown makes it stop, draining in-flight work
+// until ctx is done.
+type Server interface {
+	Name() string
+	Serve() error
+	Shutdown(ctx context.Context) error
+}
+
+// Manager starts servers together and stops them all, then releases the
+// shared resources, as soon as the process is signalled or one of them fails.
+type Manager struct {
+	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+	servers         []Server
+	shutdownHooks   []func()
+	closers         []closer
+}
+
+type closer struct {
+	name  string
+	close func() error
+}
+
+// drainDelay is how long the servers keep serving after the shutdown hooks
+// ran, giving load balancers time to notice the instance is going away.
+func NewManager(shutdownTimeout, drainDelay time.Duration) *Manager {
+	return &Manager{
+		shutdownTimeout: shutdownTimeout,
+		drainDelay:      drainDelay,
+	}
+}
+
+func (m *Manager) AddServer(server Server) {
+	m.servers = append(m.servers, server)
+}
+
+// Registers a function called as soon as a shutdown starts, before the drain delay
+func (m *Manager) AddShutdownHook(hook func()) {
+	m.shutdownHooks = append(m.shutdownHooks, hook)
+}
+
+// Registers a resource released after every server has stopped, in reverse order of registration
+func (m *Manager) AddCloser(name string, close func() error) {
+	m.closers = append(m.closers, closer{name: name, close: close})
+}
+
+// Runs every server until SIGINT/SIGTERM or the first server failure, then
+// shuts everything down. It returns the failure that stopped the process, if any.
+func (m *Manager) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, len(m.servers))
+	var wg sync.WaitGroup
+	for _, server := range m.servers {
+		wg.Add(1)
+		go func(server Server) {
+			defer wg.Done()
+			log.Printf("Starting %s", server.Name())
+			if err := server.Serve(); err != nil {
+				errCh <- errors.Join(errors.New(server.Name()+" stopped"), err)
+			}
+		}(server)
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		log.Println("Shutdown signal received")
+	case runErr = <-errCh:
+		log.Println("Server failed:", runErr)
+	}
+
+	shutdownErr := m.shutdown()
+	wg.Wait()
+
+	return errors.Join(runErr, shutdownErr)
+}
+
+func (m *Manager) shutdown() error {
+	for _, hook := range m.shutdownHooks {
+		hook()
+	}
+	if m.drainDelay > 0 {
+		log.Printf("Draining for %s before stopping servers", m.drainDelay)
+		time.Sleep(m.drainDelay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, server := range m.servers {
+		wg.Add(1)
+		go func(server Server) {
+			defer wg.Done()
+			if err := server.Shutdown(ctx); err != nil {
+				log.Printf("Failed to shut down %s: %v", server.Name(), err)
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+				return
+			}
+			log.Printf("Stopped %s", server.Name())
+		}(server)
+	}
+	wg.Wait()
+
+	if err := m.Close(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Releases the resources registered with AddCloser. Run calls it once the
+// servers have stopped; call it directly when startup fails before Run.
+func (m *Manager) Close() error {
+	var errs []error
+	for i := len(m.closers) - 1; i >= 0; i-- {
+		if err := m.closers[i].close(); err != nil {
+			log.Printf("Failed to close %s: %v", m.closers[i].name, err)
+			errs = append(errs, err)
+			continue
+		}
+		log.Printf("Closed %s", m.closers[i].name)
+	}
+
+	return errors.Join(errs...)
+}
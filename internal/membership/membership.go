@@ -0,0 +1,98 @@
+// Package membership derives loyalty tiers from the distance ledger.
+package membership
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/event"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+)
+
+// Evaluate recomputes the user's tier from their rolling window. Upgrades
+// apply at once; a user who no longer qualifies keeps their tier for the
+// grace period and is downgraded by the first evaluation after it. Tier
+// changes are recorded in the history and published as events.
+func Evaluate(ctx context.Context, userId uint64, now time.Time) (*model.Membership, error) {
+	tiers := config.AppConfig.Tier
+	membershipRepo := repository.NewMembershipRepo(config.DB)
+
+	membership, change, err := membershipRepo.Evaluate(ctx, userId, now.Add(-tiers.Window), func(m *model.Membership, activity *model.RollingActivity) *model.TierChange {
+		return apply(tiers, m, activity, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if change != nil {
+		err := event.Publish(ctx, event.Event{
+			Type:       event.TierChanged,
+			UserId:     userId,
+			OccurredAt: change.CreatedAt,
+			Data: map[string]string{
+				"from_tier": change.FromTier,
+				"to_tier":   change.ToTier,
+			},
+		})
+		if err != nil {
+			log.Println("Failed to publish tier change:", err.Error())
+		}
+	}
+
+	return membership, nil
+}
+
+// Current returns the user's membership as Evaluate would leave it at now,
+// with the activity of their rolling window, without writing anything
+func Current(ctx context.Context, userId uint64, now time.Time) (*model.Membership, *model.RollingActivity, error) {
+	tiers := config.AppConfig.Tier
+	membershipRepo := repository.NewMembershipRepo(config.DB)
+
+	membership, err := membershipRepo.Get(ctx, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+	activity, err := membershipRepo.Activity(ctx, userId, now.Add(-tiers.Window))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apply(tiers, membership, activity, now)
+	return membership, activity, nil
+}
+
+// Moves m to the tier its activity qualifies for at now and returns the
+// change, or nil when the tier stays
+func apply(tiers config.TierConfig, m *model.Membership, activity *model.RollingActivity, now time.Time) *model.TierChange {
+	qualified := tiers.Qualify(activity.Distance, activity.Trips)
+
+	if config.TierRank(qualified) >= config.TierRank(m.Tier) {
+		m.DowngradeAt = nil
+		if qualified == m.Tier {
+			return nil
+		}
+	} else {
+		// Below the current tier: start the grace period and downgrade once it is over
+		if m.DowngradeAt == nil {
+			downgradeAt := now.Add(tiers.DowngradeGrace)
+			m.DowngradeAt = &downgradeAt
+		}
+		if m.DowngradeAt.After(now) {
+			return nil
+		}
+		m.DowngradeAt = nil
+	}
+
+	change := &model.TierChange{
+		UserId:          m.UserId,
+		FromTier:        m.Tier,
+		ToTier:          qualified,
+		RollingDistance: activity.Distance,
+		RollingTrips:    activity.Trips,
+	}
+	m.Tier = qualified
+	return change
+}
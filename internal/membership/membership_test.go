@@ -0,0 +1,133 @@
+package membership
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+)
+
+func TestApply(t *testing.T) {
+	tiers := config.TierConfig{
+		SaplingMinDistance: 200,
+		SaplingMinTrips:    20,
+		ForestMinDistance:  1000,
+		ForestMinTrips:     100,
+		DowngradeGrace:     30 * 24 * time.Hour,
+	}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		at := now.Add(d)
+		return &at
+	}
+
+	tests := []struct {
+		name            string
+		tier            string
+		downgradeAt     *time.Time
+		activity        model.RollingActivity
+		wantTier        string
+		wantDowngradeAt *time.Time
+		// Tier the change moves to, "" for no change
+		wantChange string
+	}{
+		{
+			name:     "stays in the first tier",
+			tier:     config.TierSeedling,
+			activity: model.RollingActivity{Distance: 100, Trips: 50},
+			wantTier: config.TierSeedling,
+		},
+		{
+			name:       "upgrades at once",
+			tier:       config.TierSeedling,
+			activity:   model.RollingActivity{Distance: 250, Trips: 25},
+			wantTier:   config.TierSapling,
+			wantChange: config.TierSapling,
+		},
+		{
+			name:       "upgrades past a tier",
+			tier:       config.TierSeedling,
+			activity:   model.RollingActivity{Distance: 1000, Trips: 100},
+			wantTier:   config.TierForest,
+			wantChange: config.TierForest,
+		},
+		{
+			name:     "needs both distance and trips",
+			tier:     config.TierSapling,
+			activity: model.RollingActivity{Distance: 5000, Trips: 99},
+			wantTier: config.TierSapling,
+		},
+		{
+			name:            "starts the grace period when no longer qualifying",
+			tier:            config.TierSapling,
+			activity:        model.RollingActivity{Distance: 150, Trips: 25},
+			wantTier:        config.TierSapling,
+			wantDowngradeAt: at(tiers.DowngradeGrace),
+		},
+		{
+			name:            "keeps the tier during the grace period",
+			tier:            config.TierForest,
+			downgradeAt:     at(time.Hour),
+			activity:        model.RollingActivity{Distance: 500, Trips: 50},
+			wantTier:        config.TierForest,
+			wantDowngradeAt: at(time.Hour),
+		},
+		{
+			name:        "downgrades once the grace period is over",
+			tier:        config.TierForest,
+			downgradeAt: at(-time.Hour),
+			activity:    model.RollingActivity{Distance: 500, Trips: 50},
+			wantTier:    config.TierSapling,
+			wantChange:  config.TierSapling,
+		},
+		{
+			name:        "qualifying again ends the grace period",
+			tier:        config.TierSapling,
+			downgradeAt: at(time.Hour),
+			activity:    model.RollingActivity{Distance: 300, Trips: 30},
+			wantTier:    config.TierSapling,
+		},
+		{
+			name:        "upgrading ends the grace period",
+			tier:        config.TierSapling,
+			downgradeAt: at(time.Hour),
+			activity:    model.RollingActivity{Distance: 2000, Trips: 200},
+			wantTier:    config.TierForest,
+			wantChange:  config.TierForest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &model.Membership{UserId: 1, Tier: test.tier, DowngradeAt: test.downgradeAt}
+			activity := test.activity
+
+			change := apply(tiers, m, &activity, now)
+
+			if m.Tier != test.wantTier {
+				t.Errorf("tier = %q, want %q", m.Tier, test.wantTier)
+			}
+			switch {
+			case test.wantDowngradeAt == nil && m.DowngradeAt != nil:
+				t.Errorf("downgrade at = %v, want nil", *m.DowngradeAt)
+			case test.wantDowngradeAt != nil && (m.DowngradeAt == nil || !m.DowngradeAt.Equal(*test.wantDowngradeAt)):
+				t.Errorf("downgrade at = %v, want %v", m.DowngradeAt, *test.wantDowngradeAt)
+			}
+
+			if test.wantChange == "" {
+				if change != nil {
+					t.Errorf("change = %+v, want nil", change)
+				}
+				return
+			}
+			if change == nil {
+				t.Fatalf("change = nil, want to %q", test.wantChange)
+			}
+			if change.FromTier != test.tier || change.ToTier != test.wantChange || change.UserId != m.UserId ||
+				change.RollingDistance != activity.Distance || change.RollingTrips != activity.Trips {
+				t.Errorf("change = %+v, want %q to %q with the window's activity", change, test.tier, test.wantChange)
+			}
+		})
+	}
+}
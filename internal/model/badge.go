@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// UserBadge records a badge awarded to a user. Badges are defined in the
+// configuration and referenced by code.
+type UserBadge struct {
+	Id        uint64    `json:"id" gorm:"column:id; primaryKey; autoIncrement"`
+	UserId    uint64    `json:"user_id" gorm:"column:user_id;not null;uniqueIndex:idx_user_badges_user_badge,priority:1"`
+	BadgeCode string    `json:"badge_code" gorm:"column:badge_code; type:varchar(64);not null;uniqueIndex:idx_user_badges_user_badge,priority:2"`
+	AwardedAt time.Time `json:"awarded_at" gorm:"column:awarded_at;not null"`
+}
+
+func (UserBadge) TableName() string {
+	return "user_badges"
+}
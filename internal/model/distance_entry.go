@@ -0,0 +1,70 @@
+package model
+
+import (
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/distancekind"
+)
+
+// Kinds of distance entries
+const (
+	DistanceEntryTrip       = distancekind.Trip
+	DistanceEntryAdjustment = distancekind.Adjustment
+)
+
+// DistanceEntry records one change to a user's distance travelled: the
+// distance of a trip, or a signed correction made by an admin
+type DistanceEntry struct {
+	Id     uint64 `json:"id" gorm:"column:id; primaryKey; autoIncrement"`
+	UserId uint64 `json:"user_id" gorm:"column:user_id;not null;index:idx_distance_entries_user_created,priority:1"`
+	Kind   string `json:"kind" gorm:"column:kind; type:varchar(16);not null;default:trip;index:idx_distance_entries_kind_created,priority:1"`
+	// Null for adjustments, which are not tied to a trip
+	TripId   *string `json:"trip_id" gorm:"column:trip_id; type:varchar(64);uniqueIndex"`
+	Distance float64 `json:"distance" gorm:"column:distance;not null"`
+	// Empty when unknown, e.g. for adjustments
+	VehicleType string `json:"vehicle_type" gorm:"column:vehicle_type; type:varchar(16);not null;default:''"`
+	// User's total and version right after the entry, returned again on replays
+	TotalAfter   float64 `json:"total_after" gorm:"column:total_after;not null"`
+	VersionAfter uint64  `json:"version_after" gorm:"column:version_after;not null"`
+	PointsEarned int64   `json:"points_earned" gorm:"column:points_earned;not null;default:0"`
+	Reason       string  `json:"reason" gorm:"column:reason; type:varchar(255);not null;default:''"`
+	// Admin who made an adjustment
+	AdjustedBy uint64    `json:"adjusted_by" gorm:"column:adjusted_by;not null;default:0"`
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at;not null;index:idx_distance_entries_user_created,priority:2;index:idx_distance_entries_kind_created,priority:2"`
+	// Set when recording a trip again returned the entry of the first call
+	Replayed bool `json:"-" gorm:"-"`
+}
+
+func (DistanceEntry) TableName() string {
+	return "distance_entries"
+}
+
+// DistanceEntryFilter selects a page of a user's entries, newest first
+type DistanceEntryFilter struct {
+	// Entries created at or after Start and before End, when set
+	Start *time.Time
+	End   *time.Time
+	// Only entries older than this one, for the pages after the first
+	BeforeId uint64
+	Limit    int
+}
+
+// DistanceByDay is the distance of one kind a user travelled on one day in
+// one type of vehicle
+type DistanceByDay struct {
+	// Midnight starting the day, in the time zone the days were counted in
+	Day         time.Time
+	Kind        string
+	VehicleType string
+	Distance    float64
+}
+
+// DistanceByUser is the distance of one kind a user travelled in one type of vehicle
+type DistanceByUser struct {
+	UserId      uint64  `gorm:"column:user_id"`
+	Kind        string  `gorm:"column:kind"`
+	VehicleType string  `gorm:"column:vehicle_type"`
+	Distance    float64 `gorm:"column:distance"`
+	// City of the user, only set by SumByUser
+	City string `gorm:"column:city"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// EmergencyContact is a person a rider trusts to be told when they trigger an SOS.
+// Only contacts who confirmed the code sent to their phone are notified.
+type EmergencyContact struct {
+	Id     uint64 `json:"id" gorm:"column:id; primaryKey; autoIncrement"`
+	UserId uint64 `json:"user_id" gorm:"column:user_id;not null;uniqueIndex:idx_emergency_contacts_user_phone,priority:1"`
+	Name   string `json:"name" gorm:"column:name; type:varchar(50);not null"`
+	// E.164
+	PhoneNumber  string     `json:"phone_number" gorm:"column:phone_number; type:varchar(16);not null;uniqueIndex:idx_emergency_contacts_user_phone,priority:2"`
+	Relationship string     `json:"relationship" gorm:"column:relationship; type:varchar(32);not null;default:''"`
+	VerifiedAt   *time.Time `json:"verified_at" gorm:"column:verified_at"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"column:created_at;not null"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"column:updated_at;not null"`
+}
+
+func (EmergencyContact) TableName() string {
+	return "emergency_contacts"
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Metrics a goal can be set on
+const (
+	// Km travelled in the month
+	GoalMetricDistance = "distance"
+	// Grams of CO2 saved in the month
+	GoalMetricCO2Saved = "co2_saved"
+)
+
+// Goal is the target a user set themselves for one month
+type Goal struct {
+	Id     uint64 `json:"id" gorm:"column:id; primaryKey; autoIncrement"`
+	UserId uint64 `json:"user_id" gorm:"column:user_id;not null;uniqueIndex:idx_goals_user_month,priority:1"`
+	// YYYY-MM, in the user's time zone
+	Month     string    `json:"month" gorm:"column:month; type:char(7);not null;uniqueIndex:idx_goals_user_month,priority:2"`
+	Metric    string    `json:"metric" gorm:"column:metric; type:varchar(16);not null"`
+	Target    float64   `json:"target" gorm:"column:target;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;not null"`
+}
+
+func (Goal) TableName() string {
+	return "goals"
+}
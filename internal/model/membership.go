@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// Membership is the loyalty tier of a user
+type Membership struct {
+	UserId uint64 `json:"user_id" gorm:"column:user_id;primaryKey"`
+	Tier   string `json:"tier" gorm:"column:tier; type:varchar(16);not null;default:seedling"`
+	// When the tier drops to the one the user qualifies for, unless they
+	// qualify again before. Null while they keep qualifying.
+	DowngradeAt *time.Time `json:"downgrade_at" gorm:"column:downgrade_at;index"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"column:updated_at;not null"`
+}
+
+func (Membership) TableName() string {
+	return "memberships"
+}
+
+// TierChange records one change of a user's tier and the activity behind it
+type TierChange struct {
+	Id              uint64    `json:"id" gorm:"column:id; primaryKey; autoIncrement"`
+	UserId          uint64    `json:"user_id" gorm:"column:user_id;not null;index"`
+	FromTier        string    `json:"from_tier" gorm:"column:from_tier; type:varchar(16);not null"`
+	ToTier          string    `json:"to_tier" gorm:"column:to_tier; type:varchar(16);not null"`
+	RollingDistance float64   `json:"rolling_distance" gorm:"column:rolling_distance;not null"`
+	RollingTrips    int64     `json:"rolling_trips" gorm:"column:rolling_trips;not null"`
+	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at;not null"`
+}
+
+func (TierChange) TableName() string {
+	return "tier_changes"
+}
+
+// RollingActivity is what a user did during the tier window
+type RollingActivity struct {
+	Distance float64 `gorm:"column:distance"`
+	Trips    int64   `gorm:"column:trips"`
+}
@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// Kinds of points transactions
+const (
+	PointsEarn   = "earn"
+	PointsRedeem = "redeem"
+	PointsExpire = "expire"
+	PointsAdjust = "adjust"
+)
+
+// PointsTransaction is one entry of a user's points ledger. Points are signed:
+// earnings and positive adjustments add to the balance, the rest subtract.
+type PointsTransaction struct {
+	Id           uint64 `json:"id" gorm:"column:id; primaryKey; autoIncrement"`
+	UserId       uint64 `json:"user_id" gorm:"column:user_id;not null;uniqueIndex:idx_points_transactions_user_key,priority:1"`
+	Kind         string `json:"kind" gorm:"column:kind; type:varchar(16);not null"`
+	Points       int64  `json:"points" gorm:"column:points;not null"`
+	BalanceAfter int64  `json:"balance_after" gorm:"column:balance_after;not null"`
+	// Part of the points added by this entry that is neither spent nor
+	// expired yet. Spending uses the grants expiring first.
+	Remaining int64      `json:"remaining" gorm:"column:remaining;not null;default:0"`
+	ExpiresAt *time.Time `json:"expires_at" gorm:"column:expires_at;index"`
+	// Makes retried requests return the first result, unique per user.
+	// Earnings use "trip:<trip ID>".
+	IdempotencyKey *string `json:"idempotency_key" gorm:"column:idempotency_key; type:varchar(80);uniqueIndex:idx_points_transactions_user_key,priority:2"`
+	Description    string  `json:"description" gorm:"column:description; type:varchar(255);not null;default:''"`
+	// Admin who made an adjustment
+	CreatedBy uint64    `json:"created_by" gorm:"column:created_by;not null;default:0"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;not null"`
+}
+
+func (PointsTransaction) TableName() string {
+	return "points_transactions"
+}
+
+// PointsBalance is the current total of a user's points ledger
+type PointsBalance struct {
+	UserId    uint64    `json:"user_id" gorm:"column:user_id;primaryKey"`
+	Balance   int64     `json:"balance" gorm:"column:balance;not null;default:0"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;not null"`
+}
+
+func (PointsBalance) TableName() string {
+	return "points_balances"
+}
+
+// PointsTransactionFilter selects a page of a user's transactions, newest first
+type PointsTransactionFilter struct {
+	// Only transactions older than this one, for the pages after the first
+	BeforeId uint64
+	Limit    int
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// Preferences are a user's settings. Users who never changed them have no
+// row and get the configured defaults.
+type Preferences struct {
+	UserId uint64 `json:"user_id" gorm:"column:user_id;primaryKey"`
+	// Incremented by every update, 0 until the first one
+	Version uint64 `json:"version" gorm:"column:version;not null;default:0"`
+	// Language of emails and messages, one of config.Languages
+	Language string `json:"language" gorm:"column:language; type:varchar(8);not null"`
+	// km or mi
+	DistanceUnit string `json:"distance_unit" gorm:"column:distance_unit; type:varchar(8);not null"`
+
+	// Ride preferences, passed on to drivers and matching
+	QuietRide bool `json:"quiet_ride" gorm:"column:quiet_ride;not null;default:false"`
+	EVOnly    bool `json:"ev_only" gorm:"column:ev_only;not null;default:false"`
+
+	// Accessibility needs
+	WheelchairAccessible bool `json:"wheelchair_accessible" gorm:"column:wheelchair_accessible;not null;default:false"`
+	ServiceAnimal        bool `json:"service_animal" gorm:"column:service_animal;not null;default:false"`
+	HearingImpaired      bool `json:"hearing_impaired" gorm:"column:hearing_impaired;not null;default:false"`
+
+	// Notifications. Streak reminders are on by default, which is set by
+	// repository.DefaultPreferences: a gorm default would insert true for false.
+	StreakReminders bool `json:"streak_reminders" gorm:"column:streak_reminders;not null"`
+	MarketingEmails bool `json:"marketing_emails" gorm:"column:marketing_emails;not null;default:false"`
+	MarketingSMS    bool `json:"marketing_sms" gorm:"column:marketing_sms;not null;default:false"`
+
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;not null"`
+}
+
+func (Preferences) TableName() string {
+	return "user_preferences"
+}
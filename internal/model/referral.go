@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// States of a referral
+const (
+	// Until the referred user completes their first trip
+	ReferralPending  = "pending"
+	ReferralRewarded = "rewarded"
+	// Looked like fraud, never rewarded
+	ReferralRejected = "rejected"
+)
+
+// Reasons a referral is rejected
+const (
+	// The referred user signed up on the referrer's device
+	ReferralSelfReferral = "self_referral"
+	// Another account already signed up on the same device
+	ReferralDeviceReused = "device_reused"
+	// The phone number was already used to claim a referral
+	ReferralPhoneReused = "phone_reused"
+	// The referrer reached the most referrals they can be rewarded for
+	ReferralLimitReached = "limit_reached"
+)
+
+// Referral records that a user signed up with another user's referral code
+type Referral struct {
+	Id         uint64 `json:"id" gorm:"column:id; primaryKey; autoIncrement"`
+	ReferrerId uint64 `json:"referrer_id" gorm:"column:referrer_id;not null;index"`
+	// A user is referred at most once
+	RefereeId    uint64 `json:"referee_id" gorm:"column:referee_id;not null;uniqueIndex"`
+	Status       string `json:"status" gorm:"column:status; type:varchar(16);not null"`
+	RejectReason string `json:"reject_reason" gorm:"column:reject_reason; type:varchar(32);not null;default:''"`
+	// Kept to spot the same phone number or device claiming referrals again
+	RefereePhone string `json:"referee_phone" gorm:"column:referee_phone; type:varchar(16);not null;index"`
+	DeviceId     string `json:"device_id" gorm:"column:device_id; type:varchar(64);not null;default:''"`
+	// Points credited to each side when rewarded
+	ReferrerPoints int64      `json:"referrer_points" gorm:"column:referrer_points;not null;default:0"`
+	RefereePoints  int64      `json:"referee_points" gorm:"column:referee_points;not null;default:0"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"column:created_at;not null"`
+	RewardedAt     *time.Time `json:"rewarded_at" gorm:"column:rewarded_at"`
+}
+
+func (Referral) TableName() string {
+	return "referrals"
+}
+
+// ReferralStats sums up the referrals a user made
+type ReferralStats struct {
+	Pending  int64
+	Rewarded int64
+	Rejected int64
+	// Points the referrer earned from rewarded referrals
+	PointsEarned int64
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Types of saved places. A user has at most one home and one work place.
+const (
+	PlaceHome      = "home"
+	PlaceWork      = "work"
+	PlaceFavourite = "favourite"
+)
+
+// SavedPlace is an address a user picks as pickup or drop-off without typing it again
+type SavedPlace struct {
+	Id        uint64    `json:"id" gorm:"column:id; primaryKey; autoIncrement"`
+	UserId    uint64    `json:"user_id" gorm:"column:user_id;not null;index"`
+	Label     string    `json:"label" gorm:"column:label; type:varchar(50);not null"`
+	Address   string    `json:"address" gorm:"column:address; type:varchar(255);not null"`
+	Latitude  float64   `json:"latitude" gorm:"column:latitude;not null"`
+	Longitude float64   `json:"longitude" gorm:"column:longitude;not null"`
+	PlaceType string    `json:"place_type" gorm:"column:place_type; type:varchar(16);not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;not null"`
+}
+
+func (SavedPlace) TableName() string {
+	return "saved_places"
+}
@@ -1,23 +1,57 @@
 package model
 
+import "time"
+
 type User struct {
 	Id                uint64  `json:"id" gorm:"column:id; primaryKey; autoIncrement"`
 	Name              string  `json:"name" gorm:"column:name; type:varchar(50);not null"`
-	PhoneNumber       string  `json:"phone_number" gorm:"column:phone_number; type:varchar(8);unique;not null"`
+	PhoneNumber       string  `json:"phone_number" gorm:"column:phone_number; type:varchar(16);unique;not null"`
 	Email             string  `json:"email" gorm:"column:email; type:varchar(50);unique;not null"`
 	Password          string  `json:"password" gorm:"column:password; type:varchar(255);not null"`
 	DistanceTravelled float64 `json:"distance_travelled" gorm:"column:distance_travelled;default:0"`
+	Version           uint64  `json:"version" gorm:"column:version;not null;default:1"`
+	Role              string  `json:"role" gorm:"column:role; type:varchar(16);not null;default:user"`
+	// Keeps the user off the leaderboards
+	LeaderboardOptOut bool `json:"leaderboard_opt_out" gorm:"column:leaderboard_opt_out;not null;default:false"`
+	// IANA name, empty for the service's time zone
+	TimeZone string `json:"time_zone" gorm:"column:time_zone; type:varchar(64);not null;default:''"`
+	// City the user is ranked in on the city leaderboards, empty for none
+	City string `json:"city" gorm:"column:city; type:varchar(64);not null;default:''"`
+	// Given to the people the user invites, null until first needed
+	ReferralCode *string `json:"referral_code" gorm:"column:referral_code; type:varchar(16);uniqueIndex"`
+	// Device the user signed up on, empty when the client did not send one
+	SignupDeviceId string `json:"signup_device_id" gorm:"column:signup_device_id; type:varchar(64);not null;default:'';index"`
 }
 
+// Roles of users. Admins are granted in the database, never through the API.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 func (User) TableName() string {
 	return "users"
 }
 
+// Location returns the user's time zone, the service's one when they have
+// not set one or it is no longer known
+func (u *User) Location() *time.Location {
+	if u.TimeZone != "" {
+		if loc, err := time.LoadLocation(u.TimeZone); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
 type SignUpUserData struct {
-	Name        string `json:"name" gorm:"column:name; type:varchar(50);not null"`
-	PhoneNumber string `json:"phone_number" gorm:"column:phone_number; type:varchar(8);unique;not null"`
-	Email       string `json:"email" gorm:"column:email; type:varchar(50);unique;not null"`
-	Password    string `json:"password" gorm:"column:password; type:varchar(255);not null"`
+	// Set once the user is created
+	Id             uint64 `json:"id" gorm:"column:id; primaryKey; autoIncrement"`
+	Name           string `json:"name" gorm:"column:name; type:varchar(50);not null"`
+	PhoneNumber    string `json:"phone_number" gorm:"column:phone_number; type:varchar(16);unique;not null"`
+	Email          string `json:"email" gorm:"column:email; type:varchar(50);unique;not null"`
+	Password       string `json:"password" gorm:"column:password; type:varchar(255);not null"`
+	SignupDeviceId string `json:"signup_device_id" gorm:"column:signup_device_id; type:varchar(64)"`
 }
 
 func (SignUpUserData) TableName() string {
@@ -25,7 +59,7 @@ func (SignUpUserData) TableName() string {
 }
 
 type LogInUserData struct {
-	PhoneNumber string `json:"phone_number" gorm:"column:phone_number; type:varchar(8);unique;not null"`
+	PhoneNumber string `json:"phone_number" gorm:"column:phone_number; type:varchar(16);unique;not null"`
 	Password    string `json:"password" gorm:"column:password; type:varchar(255);not null"`
 }
 
@@ -35,8 +69,10 @@ func (LogInUserData) TableName() string {
 
 type UpdateUserData struct {
 	Name        string `json:"name" gorm:"column:name; type:varchar(50)"`
-	PhoneNumber string `json:"phone_number" gorm:"column:phone_number; type:varchar(8)"`
+	PhoneNumber string `json:"phone_number" gorm:"column:phone_number; type:varchar(16)"`
 	Email       string `json:"email" gorm:"column:email; type:varchar(50)"`
+	TimeZone    string `json:"time_zone" gorm:"column:time_zone; type:varchar(64)"`
+	City        string `json:"city" gorm:"column:city; type:varchar(64)"`
 }
 
 func (UpdateUserData) TableName() string {
@@ -50,11 +86,3 @@ type ChangePasswordUserData struct {
 func (ChangePasswordUserData) TableName() string {
 	return User{}.TableName()
 }
-
-type UpdateDistanceUserData struct {
-	Distance float64 `json:"distance" gorm:"column:distance_travelled"`
-}
-
-func (UpdateDistanceUserData) TableName() string {
-	return User{}.TableName()
-}
\ No newline at end of file
@@ -0,0 +1,90 @@
+// Package phone normalizes user supplied phone numbers to E.164
+// ("+6591234567") so each number has exactly one stored form.
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidNumber = errors.New("invalid phone number")
+
+// Region describes how national numbers are written in a country
+type Region struct {
+	CallingCode string `json:"calling_code"`
+	// Leading digit dropped from national numbers when dialling internationally
+	TrunkPrefix string `json:"trunk_prefix"`
+	// Allowed lengths of the national significant number
+	MinLength int `json:"min_length"`
+	MaxLength int `json:"max_length"`
+}
+
+// E.164 numbers have at most 15 digits, country code included
+const maxE164Digits = 15
+
+// Normalize returns the E.164 form of raw. Numbers starting with "+" or the
+// "00" international prefix are taken as international; anything else is a
+// national number of defaultRegion. regions are the countries national formats
+// are known for, by ISO 3166-1 alpha-2 code; international numbers of other
+// countries are only checked against the E.164 length.
+func Normalize(raw, defaultRegion string, regions map[string]Region) (string, error) {
+	raw = strings.TrimSpace(raw)
+	international := strings.HasPrefix(raw, "+")
+
+	var digits strings.Builder
+	for i, r := range raw {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", ErrInvalidNumber
+		}
+	}
+	number := digits.String()
+
+	if !international && strings.HasPrefix(number, "00") {
+		international = true
+		number = number[2:]
+	}
+
+	if international {
+		return normalizeInternational(number, regions)
+	}
+
+	region, ok := regions[defaultRegion]
+	if !ok {
+		return "", ErrInvalidNumber
+	}
+	// National significant numbers never start with the trunk prefix
+	if region.TrunkPrefix != "" {
+		number = strings.TrimPrefix(number, region.TrunkPrefix)
+	}
+	if len(number) < region.MinLength || len(number) > region.MaxLength {
+		return "", ErrInvalidNumber
+	}
+
+	return "+" + region.CallingCode + number, nil
+}
+
+func normalizeInternational(number string, regions map[string]Region) (string, error) {
+	if len(number) < 8 || len(number) > maxE164Digits || number[0] == '0' {
+		return "", ErrInvalidNumber
+	}
+
+	// Different calling codes are prefix free, so the regions matching all
+	// share one code, like the US and Canada. The number must fit one of them.
+	matched, fits := false, false
+	for _, region := range regions {
+		if national, ok := strings.CutPrefix(number, region.CallingCode); ok {
+			matched = true
+			fits = fits || (len(national) >= region.MinLength && len(national) <= region.MaxLength)
+		}
+	}
+	if matched && !fits {
+		return "", ErrInvalidNumber
+	}
+
+	return "+" + number, nil
+}
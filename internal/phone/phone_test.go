@@ -0,0 +1,61 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+)
+
+var testRegions = map[string]Region{
+	"SG": {CallingCode: "65", MinLength: 8, MaxLength: 8},
+	"VN": {CallingCode: "84", TrunkPrefix: "0", MinLength: 9, MaxLength: 10},
+	"US": {CallingCode: "1", TrunkPrefix: "1", MinLength: 10, MaxLength: 10},
+	"CA": {CallingCode: "1", TrunkPrefix: "1", MinLength: 10, MaxLength: 10},
+	"RU": {CallingCode: "7", TrunkPrefix: "8", MinLength: 10, MaxLength: 10},
+	"KZ": {CallingCode: "7", TrunkPrefix: "8", MinLength: 10, MaxLength: 10},
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name          string
+		raw           string
+		defaultRegion string
+		want          string
+		err           bool
+	}{
+		{"national", "91234567", "SG", "+6591234567", false},
+		{"national with separators", " 9123-4567 ", "SG", "+6591234567", false},
+		{"national with trunk prefix", "0912 345 678", "VN", "+84912345678", false},
+		{"national without trunk prefix", "912345678", "VN", "+84912345678", false},
+		{"national with parentheses", "1 (415) 555-0123", "US", "+14155550123", false},
+		{"national too short", "9123456", "SG", "", true},
+		{"national too long", "912345678", "SG", "", true},
+		{"unknown default region", "91234567", "XX", "", true},
+		{"international", "+65 9123 4567", "VN", "+6591234567", false},
+		{"international dialling prefix", "0065 9123 4567", "VN", "+6591234567", false},
+		{"international of unknown region", "+49 30 1234567", "SG", "+49301234567", false},
+		{"international wrong length for region", "+65 9123 456", "SG", "", true},
+		{"international of a shared calling code", "+1 604 555 0123", "SG", "+16045550123", false},
+		{"international wrong length for a shared calling code", "+7 701 123 456", "SG", "", true},
+		{"national of a region sharing its calling code", "8 (701) 123-4567", "KZ", "+77011234567", false},
+		{"international too long", "+1234567890123456", "SG", "", true},
+		{"international leading zero", "+0123456789", "SG", "", true},
+		{"plus inside number", "65+91234567", "SG", "", true},
+		{"letters", "9123abcd", "SG", "", true},
+		{"empty", "", "SG", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Normalize(test.raw, test.defaultRegion, testRegions)
+			if test.err {
+				if !errors.Is(err, ErrInvalidNumber) {
+					t.Errorf("Normalize(%q) = %q, %v, want ErrInvalidNumber", test.raw, got, err)
+				}
+				return
+			}
+			if err != nil || got != test.want {
+				t.Errorf("Normalize(%q) = %q, %v, want %q", test.raw, got, err, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Package referral records who invited whom and rewards both users once the
+// invited one completes their first trip.
+package referral
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/event"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/utils"
+	"gorm.io/gorm"
+)
+
+// Length of generated referral codes
+const codeLength = 8
+
+// Code returns the user's referral code, generating it the first time
+func Code(ctx context.Context, userId uint64) (string, error) {
+	return repository.NewUserRepo(config.DB).AssignReferralCode(ctx, userId, func() (string, error) {
+		return utils.GenerateReferralCode(codeLength)
+	})
+}
+
+// Register records in db, the sign up's transaction, that referee signed up
+// with referrer's code. Referrals that look like fraud are recorded as
+// rejected and never rewarded: sign ups on the referrer's device or on a
+// device another account signed up on, phone numbers already referred once,
+// and referrers over the limit. SignUp requires the referee's device ID.
+func Register(ctx context.Context, db *gorm.DB, referrer *model.User, referee *model.SignUpUserData) (*model.Referral, error) {
+	referralRepo := repository.NewReferralRepo(db)
+
+	history, err := loadHistory(ctx, db, referrer, referee)
+	if err != nil {
+		return nil, err
+	}
+
+	referral := &model.Referral{
+		ReferrerId:   referrer.Id,
+		RefereeId:    referee.Id,
+		Status:       model.ReferralPending,
+		RefereePhone: referee.PhoneNumber,
+		DeviceId:     referee.SignupDeviceId,
+	}
+	if reason := rejectReason(referrer, referee, history, config.AppConfig.Referral.MaxPerReferrer); reason != "" {
+		referral.Status = model.ReferralRejected
+		referral.RejectReason = reason
+		log.Printf("Rejected referral of user %d by user %d: %s", referee.Id, referrer.Id, reason)
+	}
+
+	if err := referralRepo.Create(ctx, referral); err != nil {
+		return nil, err
+	}
+	return referral, nil
+}
+
+// Reward credits both sides of the user's pending referral, if any. It is
+// called after each trip, so a failed reward is retried with the next one.
+func Reward(ctx context.Context, refereeId uint64, now time.Time) error {
+	referrals := config.AppConfig.Referral
+	expiresAt := now.Add(config.AppConfig.Points.ExpiresAfter)
+
+	referral, err := repository.NewReferralRepo(config.DB).Reward(ctx, refereeId, referrals.ReferrerPoints, referrals.RefereePoints, expiresAt)
+	if err != nil || referral == nil {
+		return err
+	}
+
+	err = event.Publish(ctx, event.Event{
+		Type:       event.ReferralRewarded,
+		UserId:     referral.ReferrerId,
+		OccurredAt: now,
+		Data: map[string]string{
+			"referee_id":      strconv.FormatUint(referral.RefereeId, 10),
+			"referrer_points": strconv.FormatInt(referral.ReferrerPoints, 10),
+			"referee_points":  strconv.FormatInt(referral.RefereePoints, 10),
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to publish reward of referral %d: %v", referral.Id, err)
+	}
+
+	return nil
+}
+
+// What earlier sign ups and referrals say about a new referral
+type history struct {
+	// Other accounts that signed up on the referee's device
+	DeviceSignups int64
+	// Referrals already claimed with the referee's phone number
+	PhoneReferrals int64
+	// Pending and rewarded referrals of the referrer
+	ReferrerAccepted int64
+}
+
+func loadHistory(ctx context.Context, db *gorm.DB, referrer *model.User, referee *model.SignUpUserData) (history, error) {
+	referralRepo := repository.NewReferralRepo(db)
+
+	var h history
+	var err error
+
+	if h.DeviceSignups, err = referralRepo.CountDeviceSignups(ctx, referee.SignupDeviceId, referee.Id); err != nil {
+		return h, err
+	}
+	if h.PhoneReferrals, err = referralRepo.CountByPhone(ctx, referee.PhoneNumber); err != nil {
+		return h, err
+	}
+	if h.ReferrerAccepted, err = referralRepo.CountAccepted(ctx, referrer.Id); err != nil {
+		return h, err
+	}
+	return h, nil
+}
+
+// Returns why a referral must be rejected, or "" when it looks genuine
+func rejectReason(referrer *model.User, referee *model.SignUpUserData, h history, maxPerReferrer int64) string {
+	if referee.SignupDeviceId == referrer.SignupDeviceId {
+		return model.ReferralSelfReferral
+	}
+	if h.DeviceSignups > 0 {
+		return model.ReferralDeviceReused
+	}
+	// A number moved to a new account cannot claim a second reward
+	if h.PhoneReferrals > 0 {
+		return model.ReferralPhoneReused
+	}
+	if h.ReferrerAccepted >= maxPerReferrer {
+		return model.ReferralLimitReached
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package referral
+
+import (
+	"testing"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+)
+
+func TestRejectReason(t *testing.T) {
+	const maxPerReferrer = 3
+
+	tests := []struct {
+		name           string
+		referrerDevice string
+		refereeDevice  string
+		history        history
+		want           string
+	}{
+		{
+			name:           "genuine",
+			referrerDevice: "device-a",
+			refereeDevice:  "device-b",
+			history:        history{ReferrerAccepted: maxPerReferrer - 1},
+		},
+		{
+			name:           "referrer without a device",
+			referrerDevice: "",
+			refereeDevice:  "device-b",
+		},
+		{
+			name:           "referrer's device",
+			referrerDevice: "device-a",
+			refereeDevice:  "device-a",
+			want:           model.ReferralSelfReferral,
+		},
+		{
+			name:           "device of another account",
+			referrerDevice: "device-a",
+			refereeDevice:  "device-b",
+			history:        history{DeviceSignups: 1},
+			want:           model.ReferralDeviceReused,
+		},
+		{
+			name:           "phone number already referred",
+			referrerDevice: "device-a",
+			refereeDevice:  "device-b",
+			history:        history{PhoneReferrals: 1},
+			want:           model.ReferralPhoneReused,
+		},
+		{
+			name:           "referrer at the limit",
+			referrerDevice: "device-a",
+			refereeDevice:  "device-b",
+			history:        history{ReferrerAccepted: maxPerReferrer},
+			want:           model.ReferralLimitReached,
+		},
+		{
+			name:           "device checks come first",
+			referrerDevice: "device-a",
+			refereeDevice:  "device-b",
+			history:        history{DeviceSignups: 2, PhoneReferrals: 1, ReferrerAccepted: maxPerReferrer},
+			want:           model.ReferralDeviceReused,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			referrer := &model.User{Id: 1, SignupDeviceId: test.referrerDevice}
+			referee := &model.SignUpUserData{Id: 2, PhoneNumber: "+6591234567", SignupDeviceId: test.refereeDevice}
+
+			if got := rejectReason(referrer, referee, test.history, maxPerReferrer); got != test.want {
+				t.Errorf("rejectReason() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
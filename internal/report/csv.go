@@ -0,0 +1,69 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSV renders the report as CSV: a summary, the ledger entries and the badges,
+// each section starting with its own header row
+func (r *EcoReport) CSV() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	records := [][]string{
+		{"Eco impact statement"},
+		{"Name", cell(r.User.Name)},
+		{"Email", cell(r.User.Email)},
+		{"Period start", r.Start.Format(time.RFC3339)},
+		{"Period end", r.End.Format(time.RFC3339)},
+		{"Generated at", r.GeneratedAt.Format(time.RFC3339)},
+		{"Trips", strconv.Itoa(r.Trips)},
+		{"Distance (km)", number(r.Distance)},
+		{"CO2 saved (g)", number(r.CO2Saved)},
+		{"Trees equivalent", number(r.Trees)},
+		{"Fuel saved (l)", number(r.FuelSaved)},
+		{},
+		{"Date", "Kind", "Trip ID", "Vehicle type", "Distance (km)", "CO2 saved (g)", "Reason"},
+	}
+	for _, entry := range r.Entries {
+		tripId := ""
+		if entry.TripId != nil {
+			tripId = *entry.TripId
+		}
+		records = append(records, []string{
+			entry.CreatedAt.Format(time.RFC3339),
+			entry.Kind,
+			cell(tripId),
+			entry.VehicleType,
+			number(entry.Distance),
+			number(entry.CO2Saved),
+			cell(entry.Reason),
+		})
+	}
+
+	records = append(records, []string{}, []string{"Badge", "Awarded at"})
+	for _, badge := range r.Badges {
+		records = append(records, []string{cell(badge.Name), badge.AwardedAt.Format(time.RFC3339)})
+	}
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Keeps spreadsheets from running user supplied text as a formula
+func cell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func number(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
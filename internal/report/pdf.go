@@ -0,0 +1,209 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A4 in points, with the margins of every page
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	pageMargin   = 50
+	footerHeight = 30
+)
+
+// Left edge of each column of the entries table
+var entryColumns = []float64{50, 165, 235, 365, 445, 505}
+
+// PDF renders the report as a PDF document using the standard Helvetica fonts,
+// so nothing has to be embedded
+func (r *EcoReport) PDF() []byte {
+	doc := newPDF()
+
+	doc.line(18, true, "Eco impact statement")
+	doc.space(6)
+	doc.line(10, false, r.User.Name+" <"+r.User.Email+">")
+	doc.line(10, false, "Period: "+r.Start.Format(time.RFC3339)+" to "+r.End.Format(time.RFC3339))
+	doc.line(10, false, "Generated at "+r.GeneratedAt.Format(time.RFC3339))
+
+	doc.space(12)
+	doc.line(13, true, "Summary")
+	doc.line(10, false, "Trips: "+strconv.Itoa(r.Trips))
+	doc.line(10, false, "Distance: "+number(r.Distance)+" km")
+	doc.line(10, false, "CO2 saved: "+number(r.CO2Saved/1000)+" kg")
+	doc.line(10, false, "Trees equivalent: "+number(r.Trees))
+	doc.line(10, false, "Fuel saved: "+number(r.FuelSaved)+" l")
+
+	doc.space(12)
+	doc.line(13, true, "Distance ledger")
+	header := []string{"Date", "Kind", "Trip ID", "Vehicle type", "Distance (km)", "CO2 (g)"}
+	doc.row(9, true, entryColumns, header)
+	for _, entry := range r.Entries {
+		tripId := ""
+		if entry.TripId != nil {
+			tripId = truncate(*entry.TripId, 22)
+		}
+		if doc.row(9, false, entryColumns, []string{
+			entry.CreatedAt.Format("2006-01-02 15:04"),
+			entry.Kind,
+			tripId,
+			entry.VehicleType,
+			number(entry.Distance),
+			number(entry.CO2Saved),
+		}) {
+			// Repeats the header at the top of each new page
+			doc.row(9, true, entryColumns, header)
+		}
+	}
+	if len(r.Entries) == 0 {
+		doc.line(9, false, "No trips in this period")
+	}
+
+	doc.space(12)
+	doc.line(13, true, "Badges earned")
+	for _, badge := range r.Badges {
+		doc.line(10, false, badge.Name+" - "+badge.AwardedAt.Format("2006-01-02"))
+	}
+	if len(r.Badges) == 0 {
+		doc.line(10, false, "No badges earned in this period")
+	}
+
+	return doc.bytes()
+}
+
+type pdfText struct {
+	x, y float64
+	size float64
+	bold bool
+	text string
+}
+
+// pdf lays out lines of text top to bottom, starting a new page when one is full
+type pdf struct {
+	pages [][]pdfText
+	y     float64
+}
+
+func newPDF() *pdf {
+	p := &pdf{}
+	p.newPage()
+	return p
+}
+
+func (p *pdf) newPage() {
+	p.pages = append(p.pages, nil)
+	p.y = pageHeight - pageMargin
+}
+
+// Moves down by the height of a line of the given size, reporting whether it
+// had to start a new page
+func (p *pdf) advance(size float64) bool {
+	height := size * 1.4
+	if p.y-height < pageMargin+footerHeight {
+		p.newPage()
+		p.y -= height
+		return true
+	}
+	p.y -= height
+	return false
+}
+
+func (p *pdf) line(size float64, bold bool, text string) {
+	p.row(size, bold, []float64{pageMargin}, []string{text})
+}
+
+func (p *pdf) row(size float64, bold bool, columns []float64, cells []string) bool {
+	paged := p.advance(size)
+	page := len(p.pages) - 1
+	for i, cell := range cells {
+		p.pages[page] = append(p.pages[page], pdfText{x: columns[i], y: p.y, size: size, bold: bold, text: cell})
+	}
+	return paged
+}
+
+func (p *pdf) space(height float64) {
+	p.y -= height
+}
+
+// Writes the document: catalog, page tree, the two fonts, then a page and its
+// content stream for each page, followed by the cross-reference table
+func (p *pdf) bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	const firstPage = 5
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, texts := range p.pages {
+		footer := fmt.Sprintf("Page %d of %d", i+1, len(p.pages))
+		texts = append(texts, pdfText{x: pageMargin, y: pageMargin, size: 8, text: footer})
+
+		var content bytes.Buffer
+		for _, t := range texts {
+			font := "F1"
+			if t.bold {
+				font = "F2"
+			}
+			fmt.Fprintf(&content, "BT /%s %g Tf %.2f %.2f Td (%s) Tj ET\n", font, t.size, t.x, t.y, pdfString(t.text))
+		}
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.Bytes()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// Escapes text for a PDF string literal. Characters outside Latin-1, which the
+// standard fonts cannot draw, are replaced by '?'.
+func pdfString(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length-1]) + "~"
+}
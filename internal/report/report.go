@@ -0,0 +1,119 @@
+// Package report renders a user's eco impact over a period, from the
+// distance ledger and their badges, as CSV or PDF.
+package report
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+)
+
+// Most ledger entries one report lists
+const MaxEntries = 10000
+
+// ErrTooManyEntries is returned by Build when the period has more than MaxEntries entries
+var ErrTooManyEntries = errors.New("too many entries in the period")
+
+// EcoReport is the content of a report, times being in the user's time zone
+type EcoReport struct {
+	User        model.User
+	Start       time.Time
+	End         time.Time
+	GeneratedAt time.Time
+
+	// Oldest first
+	Entries []Entry
+	// Badges awarded in the period, oldest first
+	Badges []Badge
+
+	Trips     int
+	Distance  float64
+	CO2Saved  float64
+	Trees     float64
+	FuelSaved float64
+}
+
+// Entry is a ledger entry with the grams of CO2 it saved
+type Entry struct {
+	model.DistanceEntry
+	CO2Saved float64
+}
+
+type Badge struct {
+	Name      string
+	AwardedAt time.Time
+}
+
+// Build gathers the user's entries and badges between start and end
+func Build(ctx context.Context, user *model.User, start, end, now time.Time) (*EcoReport, error) {
+	loc := user.Location()
+	distanceRepo := repository.NewDistanceRepo(config.DB)
+	badgeRepo := repository.NewBadgeRepo(config.DB)
+	emissions := config.AppConfig.Emissions
+
+	entries, err := distanceRepo.ListEntries(ctx, user.Id, &model.DistanceEntryFilter{
+		Start: &start,
+		End:   &end,
+		Limit: MaxEntries + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > MaxEntries {
+		return nil, ErrTooManyEntries
+	}
+
+	r := &EcoReport{
+		User:        *user,
+		Start:       start.In(loc),
+		End:         end.In(loc),
+		GeneratedAt: now.In(loc),
+	}
+
+	// Entries come newest first
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		entry.CreatedAt = entry.CreatedAt.In(loc)
+		saved := entry.Distance * emissions.SavedPerKm(entry.Kind, entry.VehicleType)
+
+		r.Entries = append(r.Entries, Entry{DistanceEntry: entry, CO2Saved: saved})
+		r.Distance += entry.Distance
+		r.CO2Saved += saved
+		if entry.Kind == model.DistanceEntryTrip {
+			r.Trips++
+		}
+	}
+	r.Trees = r.CO2Saved / emissions.TreeAbsorptionPerYear
+	r.FuelSaved = r.CO2Saved / emissions.FuelEmissionsPerLitre
+
+	badges, err := badgeRepo.List(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(config.AppConfig.Badges.Definitions))
+	for _, definition := range config.AppConfig.Badges.Definitions {
+		names[definition.Code] = definition.Name
+	}
+	for _, badge := range badges {
+		if badge.AwardedAt.Before(start) || !badge.AwardedAt.Before(end) {
+			continue
+		}
+		name := names[badge.BadgeCode]
+		if name == "" {
+			name = badge.BadgeCode
+		}
+		r.Badges = append(r.Badges, Badge{Name: name, AwardedAt: badge.AwardedAt.In(loc)})
+	}
+
+	return r, nil
+}
+
+// Filename returns the name of the report's file, without extension
+func (r *EcoReport) Filename() string {
+	return "eco-report-" + r.Start.Format("2006-01-02") + "-" + r.End.Format("2006-01-02")
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+)
+
+func TestCell(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{"Jane", "Jane"},
+		{"=HYPERLINK(\"http://x\")", "'=HYPERLINK(\"http://x\")"},
+		{"+1+2", "'+1+2"},
+		{"-1+2", "'-1+2"},
+		{"@SUM(A1:A2)", "'@SUM(A1:A2)"},
+		{"\t=1", "'\t=1"},
+		{"\r=1", "'\r=1"},
+		{"a=1", "a=1"},
+		{" =1", " =1"},
+	}
+
+	for _, test := range tests {
+		if got := cell(test.value); got != test.want {
+			t.Errorf("cell(%q) = %q, want %q", test.value, got, test.want)
+		}
+	}
+}
+
+func TestCSVEscapesUserText(t *testing.T) {
+	tripId := "=cmd|' /C calc'!A0"
+	r := testReport()
+	r.User.Name = "=1+1"
+	r.Entries = []Entry{{DistanceEntry: model.DistanceEntry{
+		Kind:        "trip",
+		TripId:      &tripId,
+		VehicleType: "ev",
+		Distance:    12.5,
+		Reason:      "@import",
+		CreatedAt:   r.Start,
+	}, CO2Saved: 1500}}
+	r.Badges = []Badge{{Name: "-bad", AwardedAt: r.Start}}
+
+	data, err := r.CSV()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := csv.NewReader(bytes.NewReader(data))
+	// Each section has its own number of columns
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("CSV() is not valid CSV: %v", err)
+	}
+
+	want := map[string]bool{"'=1+1": true, "'" + tripId: true, "'@import": true, "'-bad": true}
+	for _, record := range records {
+		for _, value := range record {
+			delete(want, value)
+		}
+	}
+	if len(want) > 0 {
+		t.Errorf("CSV() has no cells %v in %q", want, data)
+	}
+}
+
+func TestPDFString(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"", ""},
+		{"Jane Doe", "Jane Doe"},
+		{"(a) b", `\(a\) b`},
+		{`a\b`, `a\\b`},
+		{") Tj /F1 99 Tf (", `\) Tj /F1 99 Tf \(`},
+		{"line\nbreak", "line?break"},
+		{"café", "caf\xe9"},
+		{"Hải", "H?i"},
+		{"🌳", "?"},
+	}
+
+	for _, test := range tests {
+		if got := pdfString(test.text); got != test.want {
+			t.Errorf("pdfString(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		text   string
+		length int
+		want   string
+	}{
+		{"short", 10, "short"},
+		{"exactly", 7, "exactly"},
+		{"too long", 5, "too ~"},
+		{"Hải Yến", 4, "Hải~"},
+	}
+
+	for _, test := range tests {
+		if got := truncate(test.text, test.length); got != test.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", test.text, test.length, got, test.want)
+		}
+	}
+}
+
+func TestPDFEscapesUserText(t *testing.T) {
+	r := testReport()
+	r.User.Name = "Jane) Tj ("
+
+	doc := r.PDF()
+
+	if !bytes.HasPrefix(doc, []byte("%PDF-")) {
+		t.Fatalf("PDF() does not start with a PDF header: %q", doc[:min(len(doc), 16)])
+	}
+	if !bytes.Contains(doc, []byte(`Jane\) Tj \(`)) {
+		t.Error("PDF() does not escape the parentheses of the user's name")
+	}
+	if bytes.Contains(doc, []byte("Jane) Tj (")) {
+		t.Error("PDF() contains the user's name unescaped")
+	}
+}
+
+func testReport() *EcoReport {
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	return &EcoReport{
+		User:        model.User{Id: 1, Name: "Jane", Email: "jane@example.com"},
+		Start:       start,
+		End:         start.AddDate(0, 1, 0),
+		GeneratedAt: start.AddDate(0, 1, 1),
+	}
+}
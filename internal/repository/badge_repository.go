@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type badgeRepo struct {
+	db *gorm.DB
+}
+
+func NewBadgeRepo(db *gorm.DB) *badgeRepo {
+	return &badgeRepo{
+		db: db,
+	}
+}
+
+// Awards a badge to the user and reports whether they did not have it yet.
+// Awarding a badge again changes nothing.
+func (badgeRepo *badgeRepo) Award(ctx context.Context, userId uint64, code string, at time.Time) (bool, error) {
+	result := badgeRepo.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&model.UserBadge{
+		UserId:    userId,
+		BadgeCode: code,
+		AwardedAt: at,
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// Returns the badges awarded to the user, oldest first
+func (badgeRepo *badgeRepo) List(ctx context.Context, userId uint64) ([]model.UserBadge, error) {
+	var badges []model.UserBadge
+	if err := badgeRepo.db.WithContext(ctx).Where("user_id = ?", userId).Order("id").Find(&badges).Error; err != nil {
+		return nil, err
+	}
+
+	return badges, nil
+}
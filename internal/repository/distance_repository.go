@@ -0,0 +1,313 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type distanceRepo struct {
+	db *gorm.DB
+}
+
+func NewDistanceRepo(db *gorm.DB) *distanceRepo {
+	return &distanceRepo{
+		db: db,
+	}
+}
+
+// Adds the distance of a trip to the user's total, records it in the ledger
+// and credits the points it earns, in one transaction. Recording a trip again
+// changes nothing and returns the entry of the first call.
+func (distanceRepo *distanceRepo) RecordTrip(ctx context.Context, userId uint64, tripId, vehicleType string, distance float64, expectedVersion uint64, earn *PointsChange) (*model.DistanceEntry, error) {
+	entry, err := distanceRepo.recordTrip(ctx, userId, tripId, vehicleType, distance, expectedVersion, earn)
+
+	// A concurrent call recorded the trip first, its entry is the result
+	if errors.Is(err, errTripRecorded) {
+		entry = &model.DistanceEntry{}
+		if err := distanceRepo.db.WithContext(ctx).Where("trip_id = ?", tripId).First(entry).Error; err != nil {
+			return nil, err
+		}
+		return replayedEntry(entry, userId)
+	}
+
+	return entry, err
+}
+
+var errTripRecorded = errors.New("trip already recorded")
+
+func (distanceRepo *distanceRepo) recordTrip(ctx context.Context, userId uint64, tripId, vehicleType string, distance float64, expectedVersion uint64, earn *PointsChange) (*model.DistanceEntry, error) {
+	var entry *model.DistanceEntry
+
+	err := distanceRepo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existing := &model.DistanceEntry{}
+		err := tx.Where("trip_id = ?", tripId).First(existing).Error
+		if err == nil {
+			entry, err = replayedEntry(existing, userId)
+			return err
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		query := tx.Model(&model.User{}).Where("id = ?", userId)
+		if expectedVersion != 0 {
+			query = query.Where("version = ?", expectedVersion)
+		}
+
+		// Incrementing in SQL keeps concurrent trips from overwriting each other
+		result := query.Updates(map[string]interface{}{
+			"distance_travelled": gorm.Expr("distance_travelled + ?", distance),
+			"version":            gorm.Expr("version + 1"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		var user model.User
+		if err := tx.Select("distance_travelled", "version").Where("id = ?", userId).First(&user).Error; err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return VersionMismatchError(user.Version)
+		}
+
+		entry = &model.DistanceEntry{
+			UserId:       userId,
+			Kind:         model.DistanceEntryTrip,
+			TripId:       &tripId,
+			VehicleType:  vehicleType,
+			Distance:     distance,
+			TotalAfter:   user.DistanceTravelled,
+			VersionAfter: user.Version,
+			PointsEarned: earn.Points,
+		}
+		if err := tx.Create(entry).Error; err != nil {
+			if isDuplicateEntry(err) {
+				return errTripRecorded
+			}
+			return err
+		}
+
+		if earn.Points > 0 {
+			if _, err := applyPointsChange(tx, earn); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errTripRecorded) {
+		return nil, translateUserError(err)
+	}
+
+	return entry, err
+}
+
+// Adds a signed correction to the user's total and records it in the ledger,
+// refusing corrections that would make the total negative
+func (distanceRepo *distanceRepo) Adjust(ctx context.Context, userId uint64, distance float64, reason string, adminId uint64) (*model.DistanceEntry, error) {
+	var entry *model.DistanceEntry
+
+	err := distanceRepo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("distance_travelled", "version").Where("id = ?", userId).First(&user).Error; err != nil {
+			return err
+		}
+
+		if user.DistanceTravelled+distance < 0 {
+			return apperror.FailedPrecondition(apperror.ReasonNegativeDistance, "Adjustment would make the distance travelled negative").
+				WithMetadata("distance_travelled", strconv.FormatFloat(user.DistanceTravelled, 'f', -1, 64))
+		}
+
+		err := tx.Model(&model.User{}).Where("id = ?", userId).Updates(map[string]interface{}{
+			"distance_travelled": gorm.Expr("distance_travelled + ?", distance),
+			"version":            gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		entry = &model.DistanceEntry{
+			UserId:       userId,
+			Kind:         model.DistanceEntryAdjustment,
+			Distance:     distance,
+			TotalAfter:   user.DistanceTravelled + distance,
+			VersionAfter: user.Version + 1,
+			Reason:       reason,
+			AdjustedBy:   adminId,
+		}
+		return tx.Create(entry).Error
+	})
+	if err != nil {
+		return nil, translateUserError(err)
+	}
+
+	return entry, nil
+}
+
+// Returns a page of the user's entries, newest first
+func (distanceRepo *distanceRepo) ListEntries(ctx context.Context, userId uint64, filter *model.DistanceEntryFilter) ([]model.DistanceEntry, error) {
+	query := distanceRepo.db.WithContext(ctx).Where("user_id = ?", userId)
+
+	if filter.Start != nil {
+		query = query.Where("created_at >= ?", *filter.Start)
+	}
+	if filter.End != nil {
+		query = query.Where("created_at < ?", *filter.End)
+	}
+	if filter.BeforeId != 0 {
+		query = query.Where("id < ?", filter.BeforeId)
+	}
+
+	var entries []model.DistanceEntry
+	if err := query.Order("id DESC").Limit(filter.Limit).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Sums the user's distance per day in loc, kind and vehicle type, oldest day
+// first. Days are cut in Go rather than by MySQL, which knows the offset of
+// loc on each date, including across DST changes.
+func (distanceRepo *distanceRepo) SumByDay(ctx context.Context, userId uint64, loc *time.Location, start, end *time.Time) ([]model.DistanceByDay, error) {
+	query := distanceRepo.db.WithContext(ctx).
+		Select("created_at", "kind", "vehicle_type", "distance").
+		Where("user_id = ?", userId)
+
+	if start != nil {
+		query = query.Where("created_at >= ?", *start)
+	}
+	if end != nil {
+		query = query.Where("created_at < ?", *end)
+	}
+
+	var entries []model.DistanceEntry
+	if err := query.Order("created_at, id").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	type dayKey struct {
+		date, kind, vehicleType string
+	}
+	var days []model.DistanceByDay
+	index := map[dayKey]int{}
+	for _, entry := range entries {
+		at := entry.CreatedAt.In(loc)
+		key := dayKey{at.Format(time.DateOnly), entry.Kind, entry.VehicleType}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(days)
+			index[key] = i
+			days = append(days, model.DistanceByDay{
+				Day:         time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, loc),
+				Kind:        entry.Kind,
+				VehicleType: entry.VehicleType,
+			})
+		}
+		days[i].Distance += entry.Distance
+	}
+
+	return days, nil
+}
+
+// Sums the distance per user, kind and vehicle type since the given time, leaving
+// out users who opted out of the leaderboards. A userId other than 0 only
+// sums that user's distance.
+func (distanceRepo *distanceRepo) SumByUser(ctx context.Context, userId uint64, since *time.Time) ([]model.DistanceByUser, error) {
+	query := distanceRepo.db.WithContext(ctx).Table("distance_entries AS e").
+		Select("e.user_id, e.kind, e.vehicle_type, SUM(e.distance) AS distance, u.city").
+		Joins("JOIN users u ON u.id = e.user_id").
+		Where("u.leaderboard_opt_out = ?", false)
+
+	if userId != 0 {
+		query = query.Where("e.user_id = ?", userId)
+	}
+	if since != nil {
+		query = query.Where("e.created_at >= ?", *since)
+	}
+
+	var sums []model.DistanceByUser
+	if err := query.Group("e.user_id, e.kind, e.vehicle_type, u.city").Scan(&sums).Error; err != nil {
+		return nil, err
+	}
+
+	return sums, nil
+}
+
+// Sums the user's distance per kind and vehicle type, between start and end when set
+func (distanceRepo *distanceRepo) SumByVehicleType(ctx context.Context, userId uint64, start, end *time.Time) ([]model.DistanceByUser, error) {
+	query := distanceRepo.db.WithContext(ctx).Model(&model.DistanceEntry{}).
+		Select("user_id, kind, vehicle_type, SUM(distance) AS distance").
+		Where("user_id = ?", userId)
+
+	if start != nil {
+		query = query.Where("created_at >= ?", *start)
+	}
+	if end != nil {
+		query = query.Where("created_at < ?", *end)
+	}
+
+	var sums []model.DistanceByUser
+	if err := query.Group("user_id, kind, vehicle_type").Scan(&sums).Error; err != nil {
+		return nil, err
+	}
+
+	return sums, nil
+}
+
+// Counts the user's trips
+func (distanceRepo *distanceRepo) CountTrips(ctx context.Context, userId uint64) (int64, error) {
+	var trips int64
+	err := distanceRepo.db.WithContext(ctx).Model(&model.DistanceEntry{}).
+		Where("user_id = ? AND kind = ?", userId, model.DistanceEntryTrip).
+		Count(&trips).Error
+	return trips, err
+}
+
+// Returns the times of the user's trips since a time, newest first
+func (distanceRepo *distanceRepo) TripTimes(ctx context.Context, userId uint64, since time.Time) ([]time.Time, error) {
+	var times []time.Time
+	err := distanceRepo.db.WithContext(ctx).Model(&model.DistanceEntry{}).
+		Where("user_id = ? AND kind = ? AND created_at >= ?", userId, model.DistanceEntryTrip, since).
+		Order("created_at DESC").
+		Pluck("created_at", &times).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return times, nil
+}
+
+// Returns up to limit users, by ID after afterUserId, who took a trip since a time
+func (distanceRepo *distanceRepo) ListTravellers(ctx context.Context, since time.Time, afterUserId uint64, limit int) ([]uint64, error) {
+	var ids []uint64
+	err := distanceRepo.db.WithContext(ctx).Model(&model.DistanceEntry{}).
+		Distinct("user_id").
+		Where("kind = ? AND created_at >= ? AND user_id > ?", model.DistanceEntryTrip, since, afterUserId).
+		Order("user_id").
+		Limit(limit).
+		Pluck("user_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Returns the entry of an already recorded trip, which must be the user's own
+func replayedEntry(entry *model.DistanceEntry, userId uint64) (*model.DistanceEntry, error) {
+	if entry.UserId != userId {
+		return nil, apperror.AlreadyExists(apperror.ReasonTripAlreadyRecorded, "Trip was already recorded for another user")
+	}
+	entry.Replayed = true
+	return entry, nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/testdb"
+)
+
+func TestRecordTripReplay(t *testing.T) {
+	ctx := context.Background()
+	db := testdb.Open(t)
+	distanceRepo := NewDistanceRepo(db)
+
+	user := &model.User{Name: "Jane", PhoneNumber: "+6591234567", Email: "jane@example.com", Password: "hash"}
+	other := &model.User{Name: "John", PhoneNumber: "+6598765432", Email: "john@example.com", Password: "hash"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(other).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	earn := func(userId uint64) *PointsChange {
+		return &PointsChange{UserId: userId, Kind: model.PointsEarn, Points: 12, IdempotencyKey: "trip:trip-1", ExpiresAt: time.Now().Add(time.Hour)}
+	}
+
+	first, err := distanceRepo.RecordTrip(ctx, user.Id, "trip-1", "ev", 12.5, 0, earn(user.Id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Replayed || first.TotalAfter != 12.5 {
+		t.Errorf("RecordTrip() = replayed %v with total %v, want a new entry with 12.5", first.Replayed, first.TotalAfter)
+	}
+
+	// The trip service retries with the same trip, even with another distance
+	again, err := distanceRepo.RecordTrip(ctx, user.Id, "trip-1", "ev", 20, 0, earn(user.Id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Replayed || again.Id != first.Id || again.Distance != 12.5 || again.TotalAfter != 12.5 {
+		t.Errorf("RecordTrip() again = %+v, want entry %d replayed", again, first.Id)
+	}
+
+	_, err = distanceRepo.RecordTrip(ctx, other.Id, "trip-1", "ev", 12.5, 0, earn(other.Id))
+	if !apperror.HasReason(err, apperror.ReasonTripAlreadyRecorded) {
+		t.Errorf("RecordTrip() for another user = %v, want %s", err, apperror.ReasonTripAlreadyRecorded)
+	}
+
+	stored := &model.User{Id: user.Id}
+	if err := db.First(stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.DistanceTravelled != 12.5 || stored.Version != first.VersionAfter {
+		t.Errorf("user has %v km at version %d, want 12.5 at %d", stored.DistanceTravelled, stored.Version, first.VersionAfter)
+	}
+
+	entries, err := distanceRepo.ListEntries(ctx, user.Id, &model.DistanceEntryFilter{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Id != first.Id {
+		t.Errorf("ListEntries() = %d entries, want only entry %d", len(entries), first.Id)
+	}
+	if entries, err := distanceRepo.ListEntries(ctx, other.Id, &model.DistanceEntryFilter{Limit: 10}); err != nil || len(entries) != 0 {
+		t.Errorf("ListEntries() of the other user = %d entries, %v, want none", len(entries), err)
+	}
+
+	balance, _, err := NewPointsRepo(db).GetBalance(ctx, user.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance != 12 {
+		t.Errorf("points balance = %d, want 12 earned once", balance)
+	}
+}
+
+func TestSumByDayAcrossDST(t *testing.T) {
+	db := testdb.Open(t)
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clocks moved forward on March 8, 2026: the evenings around it are 5
+	// and 4 hours behind UTC
+	for i, entry := range []struct {
+		at          string
+		vehicleType string
+		distance    float64
+	}{
+		{"2026-03-07T23:30:00-05:00", "ev", 1},
+		{"2026-03-08T00:30:00-05:00", "ev", 2},
+		{"2026-03-08T23:30:00-04:00", "ev", 4},
+		{"2026-03-08T23:45:00-04:00", "hybrid", 8},
+		{"2026-03-09T00:15:00-04:00", "ev", 16},
+	} {
+		at, err := time.Parse(time.RFC3339, entry.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tripId := fmt.Sprintf("trip-%d", i)
+		err = db.Create(&model.DistanceEntry{
+			UserId:      1,
+			Kind:        model.DistanceEntryTrip,
+			TripId:      &tripId,
+			VehicleType: entry.vehicleType,
+			Distance:    entry.distance,
+			CreatedAt:   at,
+		}).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	days, err := NewDistanceRepo(db).SumByDay(context.Background(), 1, loc, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []model.DistanceByDay{
+		{Day: time.Date(2026, 3, 7, 0, 0, 0, 0, loc), Kind: model.DistanceEntryTrip, VehicleType: "ev", Distance: 1},
+		{Day: time.Date(2026, 3, 8, 0, 0, 0, 0, loc), Kind: model.DistanceEntryTrip, VehicleType: "ev", Distance: 6},
+		{Day: time.Date(2026, 3, 8, 0, 0, 0, 0, loc), Kind: model.DistanceEntryTrip, VehicleType: "hybrid", Distance: 8},
+		{Day: time.Date(2026, 3, 9, 0, 0, 0, 0, loc), Kind: model.DistanceEntryTrip, VehicleType: "ev", Distance: 16},
+	}
+	if len(days) != len(want) {
+		t.Fatalf("SumByDay() = %+v, want %+v", days, want)
+	}
+	for i := range want {
+		if !days[i].Day.Equal(want[i].Day) || days[i].Kind != want[i].Kind || days[i].VehicleType != want[i].VehicleType || days[i].Distance != want[i].Distance {
+			t.Errorf("SumByDay()[%d] = %+v, want %+v", i, days[i], want[i])
+		}
+	}
+}
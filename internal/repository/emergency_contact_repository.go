@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type emergencyContactRepo struct {
+	db *gorm.DB
+}
+
+func NewEmergencyContactRepo(db *gorm.DB) *emergencyContactRepo {
+	return &emergencyContactRepo{
+		db: db,
+	}
+}
+
+// Adds a contact unless the user already has maxPerUser contacts
+func (contactRepo *emergencyContactRepo) Create(ctx context.Context, contact *model.EmergencyContact, maxPerUser int64) error {
+	return contactRepo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockUser(tx, contact.UserId); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&model.EmergencyContact{}).Where("user_id = ?", contact.UserId).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= maxPerUser {
+			return apperror.ResourceExhausted(apperror.ReasonLimitExceeded,
+				"A user can have at most "+strconv.FormatInt(maxPerUser, 10)+" emergency contacts")
+		}
+
+		return translateContactError(tx.Create(contact).Error)
+	})
+}
+
+// Returns one of the user's contacts
+func (contactRepo *emergencyContactRepo) Get(ctx context.Context, userId, contactId uint64) (*model.EmergencyContact, error) {
+	contact := &model.EmergencyContact{}
+	err := contactRepo.db.WithContext(ctx).Where("id = ? AND user_id = ?", contactId, userId).First(contact).Error
+	if err != nil {
+		return nil, translateContactError(err)
+	}
+	return contact, nil
+}
+
+// Returns the user's contacts in the order they were added, only the verified
+// ones when verifiedOnly is set
+func (contactRepo *emergencyContactRepo) List(ctx context.Context, userId uint64, verifiedOnly bool) ([]model.EmergencyContact, error) {
+	query := contactRepo.db.WithContext(ctx).Where("user_id = ?", userId)
+	if verifiedOnly {
+		query = query.Where("verified_at IS NOT NULL")
+	}
+
+	var contacts []model.EmergencyContact
+	err := query.Order("id").Find(&contacts).Error
+	return contacts, err
+}
+
+// Updates the given fields of one of the user's contacts, then reloads it.
+// A new phone number has to be verified again; returns whether there was one.
+func (contactRepo *emergencyContactRepo) Update(ctx context.Context, contact *model.EmergencyContact, fields []string) (bool, error) {
+	phoneChanged := false
+	err := contactRepo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current := &model.EmergencyContact{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", contact.Id, contact.UserId).
+			First(current).Error
+		if err != nil {
+			return translateContactError(err)
+		}
+
+		values := map[string]interface{}{"updated_at": time.Now()}
+		for _, field := range fields {
+			switch field {
+			case "name":
+				values["name"] = contact.Name
+			case "relationship":
+				values["relationship"] = contact.Relationship
+			case "phone_number":
+				if contact.PhoneNumber != current.PhoneNumber {
+					values["phone_number"] = contact.PhoneNumber
+					values["verified_at"] = nil
+					phoneChanged = true
+				}
+			}
+		}
+
+		if err := tx.Model(current).Updates(values).Error; err != nil {
+			return translateContactError(err)
+		}
+		return tx.Where("id = ?", contact.Id).First(contact).Error
+	})
+	return phoneChanged, err
+}
+
+// Marks a contact verified, provided its phone number is still the one the
+// code was sent to. Returns whether it was.
+func (contactRepo *emergencyContactRepo) MarkVerified(ctx context.Context, contactId uint64, phoneNumber string) (bool, error) {
+	result := contactRepo.db.WithContext(ctx).Model(&model.EmergencyContact{}).
+		Where("id = ? AND phone_number = ?", contactId, phoneNumber).
+		Updates(map[string]interface{}{"verified_at": time.Now(), "updated_at": time.Now()})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Deletes one of the user's contacts
+func (contactRepo *emergencyContactRepo) Delete(ctx context.Context, userId, contactId uint64) error {
+	result := contactRepo.db.WithContext(ctx).Where("id = ? AND user_id = ?", contactId, userId).Delete(&model.EmergencyContact{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return contactNotFoundError()
+	}
+	return nil
+}
+
+func translateContactError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return contactNotFoundError()
+	case isDuplicateEntry(err):
+		return apperror.AlreadyExists(apperror.ReasonContactExists, "This phone number is already one of the user's emergency contacts")
+	}
+	return err
+}
+
+func contactNotFoundError() error {
+	return apperror.NotFound(apperror.ReasonContactNotFound, "Emergency contact not found")
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"gorm.io/gorm"
+)
+
+// MySQL error number for unique constraint violations
+const mysqlDuplicateEntry = 1062
+
+// Translates the database errors the service knows how to explain into
+// domain errors; anything else is returned unchanged
+func translateUserError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperror.NotFound(apperror.ReasonUserNotFound, "User not found")
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if isDuplicateEntry(err) && errors.As(err, &mysqlErr) {
+		switch {
+		case strings.Contains(mysqlErr.Message, "phone_number"):
+			return contactTakenError("phone_number")
+		case strings.Contains(mysqlErr.Message, "email"):
+			return contactTakenError("email")
+		}
+	}
+
+	return err
+}
+
+// Reports whether err is a unique constraint violation
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
+
+// Error for an email or phone number registered to another user
+func contactTakenError(field string) error {
+	if field == "phone_number" {
+		return apperror.AlreadyExists(apperror.ReasonPhoneNumberTaken, "Phone number is already registered")
+	}
+	return apperror.AlreadyExists(apperror.ReasonEmailTaken, "Email is already registered")
+}
+
+// Error for an update made against an outdated version of the user
+func VersionMismatchError(current uint64) error {
+	return apperror.Aborted(apperror.ReasonVersionMismatch, "User was modified by another request, fetch it again and retry").
+		WithMetadata("current_version", strconv.FormatUint(current, 10))
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type goalRepo struct {
+	db *gorm.DB
+}
+
+func NewGoalRepo(db *gorm.DB) *goalRepo {
+	return &goalRepo{
+		db: db,
+	}
+}
+
+// Sets the user's goal for the goal's month, replacing any previous one
+func (goalRepo *goalRepo) Set(ctx context.Context, goal *model.Goal) error {
+	return goalRepo.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "month"}},
+		DoUpdates: clause.AssignmentColumns([]string{"metric", "target", "updated_at"}),
+	}).Create(goal).Error
+}
+
+// Returns the user's goal for a month, nil when they set none
+func (goalRepo *goalRepo) Get(ctx context.Context, userId uint64, month string) (*model.Goal, error) {
+	goal := &model.Goal{}
+	err := goalRepo.db.WithContext(ctx).Where("user_id = ? AND month = ?", userId, month).First(goal).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return goal, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type membershipRepo struct {
+	db *gorm.DB
+}
+
+func NewMembershipRepo(db *gorm.DB) *membershipRepo {
+	return &membershipRepo{
+		db: db,
+	}
+}
+
+// Returns the user's membership, the first tier when none was recorded yet
+func (membershipRepo *membershipRepo) Get(ctx context.Context, userId uint64) (*model.Membership, error) {
+	membership := &model.Membership{}
+	err := membershipRepo.db.WithContext(ctx).Where("user_id = ?", userId).First(membership).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &model.Membership{UserId: userId, Tier: config.TierSeedling}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+// Sums the user's distance and counts their trips since a time
+func (membershipRepo *membershipRepo) Activity(ctx context.Context, userId uint64, since time.Time) (*model.RollingActivity, error) {
+	return rollingActivity(membershipRepo.db.WithContext(ctx), userId, since)
+}
+
+func rollingActivity(db *gorm.DB, userId uint64, since time.Time) (*model.RollingActivity, error) {
+	activity := &model.RollingActivity{}
+	err := db.Model(&model.DistanceEntry{}).
+		Select("COALESCE(SUM(distance), 0) AS distance, COUNT(trip_id) AS trips").
+		Where("user_id = ? AND created_at >= ?", userId, since).
+		Scan(activity).Error
+	if err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
+
+// Locks the user's membership and passes it with their activity since a time
+// to evaluate, which changes it in place and returns the tier change to
+// record, if any. Returns the saved membership and the recorded change.
+func (membershipRepo *membershipRepo) Evaluate(ctx context.Context, userId uint64, since time.Time, evaluate func(*model.Membership, *model.RollingActivity) *model.TierChange) (*model.Membership, *model.TierChange, error) {
+	membership := &model.Membership{}
+	var change *model.TierChange
+
+	err := membershipRepo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.Membership{UserId: userId, Tier: config.TierSeedling}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userId).First(membership).Error; err != nil {
+			return err
+		}
+
+		activity, err := rollingActivity(tx, userId, since)
+		if err != nil {
+			return err
+		}
+
+		change = evaluate(membership, activity)
+
+		// Select writes downgrade_at even when it was cleared
+		if err := tx.Model(membership).Select("tier", "downgrade_at", "updated_at").Updates(membership).Error; err != nil {
+			return err
+		}
+		if change != nil {
+			return tx.Create(change).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return membership, change, nil
+}
+
+// Returns the tier changes of the user, newest first
+func (membershipRepo *membershipRepo) History(ctx context.Context, userId uint64, limit int) ([]model.TierChange, error) {
+	var changes []model.TierChange
+	err := membershipRepo.db.WithContext(ctx).Where("user_id = ?", userId).Order("id DESC").Limit(limit).Find(&changes).Error
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// Returns up to limit users after afterUserId whose tier may drop as their
+// window slides: those above the first tier
+func (membershipRepo *membershipRepo) ListForReview(ctx context.Context, afterUserId uint64, limit int) ([]uint64, error) {
+	var ids []uint64
+	err := membershipRepo.db.WithContext(ctx).Model(&model.Membership{}).
+		Where("user_id > ? AND tier <> ?", afterUserId, config.TierSeedling).
+		Order("user_id").
+		Limit(limit).
+		Pluck("user_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type placeRepo struct {
+	db *gorm.DB
+}
+
+func NewPlaceRepo(db *gorm.DB) *placeRepo {
+	return &placeRepo{
+		db: db,
+	}
+}
+
+// Saves a new place unless the user already has maxPerUser places, or
+// already has a place of the same type when it is home or work
+func (placeRepo *placeRepo) Create(ctx context.Context, place *model.SavedPlace, maxPerUser int64) error {
+	return placeRepo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockUser(tx, place.UserId); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&model.SavedPlace{}).Where("user_id = ?", place.UserId).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= maxPerUser {
+			return apperror.ResourceExhausted(apperror.ReasonLimitExceeded,
+				"A user can save at most "+strconv.FormatInt(maxPerUser, 10)+" places")
+		}
+
+		if err := checkPlaceType(tx, place); err != nil {
+			return err
+		}
+		return tx.Create(place).Error
+	})
+}
+
+// Returns the user's places, home and work first, then by label
+func (placeRepo *placeRepo) List(ctx context.Context, userId uint64) ([]model.SavedPlace, error) {
+	var places []model.SavedPlace
+	err := placeRepo.db.WithContext(ctx).
+		Where("user_id = ?", userId).
+		Order("CASE place_type WHEN '" + model.PlaceHome + "' THEN 0 WHEN '" + model.PlaceWork + "' THEN 1 ELSE 2 END").
+		Order("label").Order("id").
+		Find(&places).Error
+	return places, err
+}
+
+// Updates the given fields of one of the user's places, then reloads it
+func (placeRepo *placeRepo) Update(ctx context.Context, place *model.SavedPlace, fields []string) error {
+	return placeRepo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockUser(tx, place.UserId); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&model.SavedPlace{}).Where("id = ? AND user_id = ?", place.Id, place.UserId).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return savedPlaceNotFoundError()
+		}
+
+		if slices.Contains(fields, "place_type") {
+			if err := checkPlaceType(tx, place); err != nil {
+				return err
+			}
+		}
+
+		values := map[string]interface{}{"updated_at": time.Now()}
+		for _, field := range fields {
+			switch field {
+			case "label":
+				values["label"] = place.Label
+			case "address":
+				values["address"] = place.Address
+			case "latitude":
+				values["latitude"] = place.Latitude
+			case "longitude":
+				values["longitude"] = place.Longitude
+			case "place_type":
+				values["place_type"] = place.PlaceType
+			}
+		}
+		if err := tx.Model(&model.SavedPlace{}).Where("id = ?", place.Id).Updates(values).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", place.Id).First(place).Error
+	})
+}
+
+// Deletes one of the user's places
+func (placeRepo *placeRepo) Delete(ctx context.Context, userId, placeId uint64) error {
+	result := placeRepo.db.WithContext(ctx).Where("id = ? AND user_id = ?", placeId, userId).Delete(&model.SavedPlace{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return savedPlaceNotFoundError()
+	}
+	return nil
+}
+
+// Locks the user's row for the transaction, so concurrent changes to what the
+// user owns cannot both pass the limits
+func lockUser(tx *gorm.DB, userId uint64) error {
+	var user model.User
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", userId).First(&user).Error
+	return translateUserError(err)
+}
+
+// Rejects a second home or work place
+func checkPlaceType(tx *gorm.DB, place *model.SavedPlace) error {
+	if place.PlaceType != model.PlaceHome && place.PlaceType != model.PlaceWork {
+		return nil
+	}
+
+	var existing model.SavedPlace
+	err := tx.Select("id").
+		Where("user_id = ? AND place_type = ? AND id <> ?", place.UserId, place.PlaceType, place.Id).
+		First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return apperror.AlreadyExists(apperror.ReasonPlaceTypeTaken, "A "+place.PlaceType+" place is already saved, update it instead").
+		WithMetadata("place_id", strconv.FormatUint(existing.Id, 10))
+}
+
+func savedPlaceNotFoundError() error {
+	return apperror.NotFound(apperror.ReasonSavedPlaceNotFound, "Saved place not found")
+}
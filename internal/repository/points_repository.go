@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type pointsRepo struct {
+	db *gorm.DB
+}
+
+func NewPointsRepo(db *gorm.DB) *pointsRepo {
+	return &pointsRepo{
+		db: db,
+	}
+}
+
+// PointsChange describes a transaction to add to a user's ledger
+type PointsChange struct {
+	UserId uint64
+	Kind   string
+	// Positive to add points, negative to spend them
+	Points         int64
+	IdempotencyKey string
+	Description    string
+	CreatedBy      uint64
+	// When added points expire
+	ExpiresAt time.Time
+}
+
+// Adds a transaction to the ledger and updates the balance. A change whose
+// idempotency key was already used returns the transaction recorded first.
+func (pointsRepo *pointsRepo) Apply(ctx context.Context, change *PointsChange) (*model.PointsTransaction, error) {
+	var txn *model.PointsTransaction
+
+	err := pointsRepo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		txn, err = applyPointsChange(tx, change)
+		return err
+	})
+
+	// A concurrent call with the same key won the race, its transaction is the result
+	if isDuplicateEntry(err) {
+		existing := &model.PointsTransaction{}
+		if err := pointsRepo.db.WithContext(ctx).Where("user_id = ? AND idempotency_key = ?", change.UserId, change.IdempotencyKey).First(existing).Error; err != nil {
+			return nil, err
+		}
+		return replayedTransaction(existing, change)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return txn, nil
+}
+
+// Applies a change within tx, which callers use to earn points in the same
+// transaction as the trip they are earned for
+func applyPointsChange(tx *gorm.DB, change *PointsChange) (*model.PointsTransaction, error) {
+	if change.IdempotencyKey != "" {
+		existing := &model.PointsTransaction{}
+		err := tx.Where("user_id = ? AND idempotency_key = ?", change.UserId, change.IdempotencyKey).First(existing).Error
+		if err == nil {
+			return replayedTransaction(existing, change)
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	balance, err := lockBalance(tx, change.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	txn := &model.PointsTransaction{
+		UserId:       change.UserId,
+		Kind:         change.Kind,
+		Points:       change.Points,
+		BalanceAfter: balance.Balance + change.Points,
+		Description:  change.Description,
+		CreatedBy:    change.CreatedBy,
+	}
+	if change.IdempotencyKey != "" {
+		txn.IdempotencyKey = &change.IdempotencyKey
+	}
+
+	if change.Points > 0 {
+		expiresAt := change.ExpiresAt
+		txn.ExpiresAt = &expiresAt
+		txn.Remaining = change.Points
+	} else if change.Points < 0 {
+		if balance.Balance+change.Points < 0 {
+			return nil, apperror.FailedPrecondition(apperror.ReasonInsufficientPoints, "Not enough points").
+				WithMetadata("balance", strconv.FormatInt(balance.Balance, 10))
+		}
+		if err := consumeGrants(tx, change.UserId, -change.Points); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Create(txn).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Model(balance).Update("balance", txn.BalanceAfter).Error; err != nil {
+		return nil, err
+	}
+
+	return txn, nil
+}
+
+// Returns the balance row of the user locked for update, creating it at 0
+func lockBalance(tx *gorm.DB, userId uint64) (*model.PointsBalance, error) {
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.PointsBalance{UserId: userId}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	balance := &model.PointsBalance{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userId).First(balance).Error; err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// Takes points from the remaining part of the user's grants, those expiring first first
+func consumeGrants(tx *gorm.DB, userId uint64, points int64) error {
+	var grants []model.PointsTransaction
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND remaining > 0", userId).
+		Order("expires_at, id").
+		Find(&grants).Error
+	if err != nil {
+		return err
+	}
+
+	for i := range grants {
+		if points == 0 {
+			break
+		}
+		taken := min(points, grants[i].Remaining)
+		if err := tx.Model(&grants[i]).Update("remaining", grants[i].Remaining-taken).Error; err != nil {
+			return err
+		}
+		points -= taken
+	}
+
+	return nil
+}
+
+// Returns the transaction recorded for an idempotency key, which must have
+// been used for the same change
+func replayedTransaction(txn *model.PointsTransaction, change *PointsChange) (*model.PointsTransaction, error) {
+	if txn.Kind != change.Kind || txn.Points != change.Points {
+		return nil, apperror.FailedPrecondition(apperror.ReasonIdempotencyKeyReused, "Idempotency key was already used for a different request")
+	}
+	return txn, nil
+}
+
+// Expires the remaining points of up to limit grants that expired before
+// now and returns how many grants were expired
+func (pointsRepo *pointsRepo) ExpireGrants(ctx context.Context, now time.Time, limit int) (int, error) {
+	var listed []model.PointsTransaction
+	err := pointsRepo.db.WithContext(ctx).
+		Select("id", "user_id").
+		Where("remaining > 0 AND expires_at <= ?", now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&listed).Error
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, listedGrant := range listed {
+		err := pointsRepo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			// Balance first, then grants, in the same order as redemptions
+			balance, err := lockBalance(tx, listedGrant.UserId)
+			if err != nil {
+				return err
+			}
+
+			grant := &model.PointsTransaction{}
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", listedGrant.Id).First(grant).Error; err != nil {
+				return err
+			}
+			// Spent or expired by another replica since it was listed
+			if grant.Remaining == 0 {
+				return nil
+			}
+
+			txn := &model.PointsTransaction{
+				UserId:       grant.UserId,
+				Kind:         model.PointsExpire,
+				Points:       -grant.Remaining,
+				BalanceAfter: balance.Balance - grant.Remaining,
+				Description:  "Points earned on " + grant.CreatedAt.Format("2006-01-02") + " expired",
+			}
+			if err := tx.Create(txn).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(grant).Update("remaining", 0).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(balance).Update("balance", txn.BalanceAfter).Error; err != nil {
+				return err
+			}
+
+			expired++
+			return nil
+		})
+		if err != nil {
+			return expired, err
+		}
+	}
+
+	return expired, nil
+}
+
+// Returns the user's balance and the grant expiring next, if any
+func (pointsRepo *pointsRepo) GetBalance(ctx context.Context, userId uint64) (int64, *model.PointsTransaction, error) {
+	balance := &model.PointsBalance{}
+	err := pointsRepo.db.WithContext(ctx).Where("user_id = ?", userId).First(balance).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil, nil
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	next := &model.PointsTransaction{}
+	err = pointsRepo.db.WithContext(ctx).Where("user_id = ? AND remaining > 0", userId).Order("expires_at, id").First(next).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return balance.Balance, nil, nil
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return balance.Balance, next, nil
+}
+
+// Returns a page of the user's transactions, newest first
+func (pointsRepo *pointsRepo) ListTransactions(ctx context.Context, userId uint64, filter *model.PointsTransactionFilter) ([]model.PointsTransaction, error) {
+	query := pointsRepo.db.WithContext(ctx).Where("user_id = ?", userId)
+	if filter.BeforeId != 0 {
+		query = query.Where("id < ?", filter.BeforeId)
+	}
+
+	var txns []model.PointsTransaction
+	if err := query.Order("id DESC").Limit(filter.Limit).Find(&txns).Error; err != nil {
+		return nil, err
+	}
+
+	return txns, nil
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/testdb"
+)
+
+const pointsUserId = 1
+
+func TestApplyReplaysIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	pointsRepo := NewPointsRepo(testdb.Open(t))
+	grantPoints(t, pointsRepo, 100, time.Now().Add(time.Hour))
+
+	redeem := &PointsChange{UserId: pointsUserId, Kind: model.PointsRedeem, Points: -30, IdempotencyKey: "order-1"}
+	first, err := pointsRepo.Apply(ctx, redeem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := pointsRepo.Apply(ctx, redeem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Id != first.Id || again.BalanceAfter != 70 {
+		t.Errorf("Apply() again = transaction %d with balance %d, want %d with 70", again.Id, again.BalanceAfter, first.Id)
+	}
+	wantBalance(t, pointsRepo, 70)
+
+	_, err = pointsRepo.Apply(ctx, &PointsChange{UserId: pointsUserId, Kind: model.PointsRedeem, Points: -40, IdempotencyKey: "order-1"})
+	if !apperror.HasReason(err, apperror.ReasonIdempotencyKeyReused) {
+		t.Errorf("Apply() of another change with the key = %v, want %s", err, apperror.ReasonIdempotencyKeyReused)
+	}
+	wantBalance(t, pointsRepo, 70)
+}
+
+func TestApplySpendsGrantsExpiringFirst(t *testing.T) {
+	ctx := context.Background()
+	pointsRepo := NewPointsRepo(testdb.Open(t))
+	now := time.Now()
+	late := grantPoints(t, pointsRepo, 50, now.Add(30*24*time.Hour))
+	early := grantPoints(t, pointsRepo, 50, now.Add(10*24*time.Hour))
+	middle := grantPoints(t, pointsRepo, 50, now.Add(20*24*time.Hour))
+
+	if _, err := pointsRepo.Apply(ctx, &PointsChange{UserId: pointsUserId, Kind: model.PointsRedeem, Points: -70}); err != nil {
+		t.Fatal(err)
+	}
+
+	wantRemaining(t, pointsRepo, map[uint64]int64{early.Id: 0, middle.Id: 30, late.Id: 50})
+	wantBalance(t, pointsRepo, 80)
+
+	_, err := pointsRepo.Apply(ctx, &PointsChange{UserId: pointsUserId, Kind: model.PointsRedeem, Points: -81})
+	if !apperror.HasReason(err, apperror.ReasonInsufficientPoints) {
+		t.Errorf("Apply() of more than the balance = %v, want %s", err, apperror.ReasonInsufficientPoints)
+	}
+	wantRemaining(t, pointsRepo, map[uint64]int64{early.Id: 0, middle.Id: 30, late.Id: 50})
+}
+
+func TestExpireGrantsAfterSpending(t *testing.T) {
+	ctx := context.Background()
+	pointsRepo := NewPointsRepo(testdb.Open(t))
+	now := time.Now()
+	expiring := grantPoints(t, pointsRepo, 50, now.Add(time.Hour))
+	lasting := grantPoints(t, pointsRepo, 50, now.Add(30*24*time.Hour))
+
+	// Spent from the grant expiring first, so only its rest expires
+	if _, err := pointsRepo.Apply(ctx, &PointsChange{UserId: pointsUserId, Kind: model.PointsRedeem, Points: -30}); err != nil {
+		t.Fatal(err)
+	}
+
+	expired, err := pointsRepo.ExpireGrants(ctx, now.Add(2*time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expired != 1 {
+		t.Errorf("ExpireGrants() = %d, want 1", expired)
+	}
+	wantRemaining(t, pointsRepo, map[uint64]int64{expiring.Id: 0, lasting.Id: 50})
+	wantBalance(t, pointsRepo, 50)
+
+	txns, err := pointsRepo.ListTransactions(ctx, pointsUserId, &model.PointsTransactionFilter{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txns) != 1 || txns[0].Kind != model.PointsExpire || txns[0].Points != -20 || txns[0].BalanceAfter != 50 {
+		t.Errorf("last transaction = %+v, want 20 points expired", txns)
+	}
+
+	// Expiring again finds nothing left
+	if expired, err := pointsRepo.ExpireGrants(ctx, now.Add(2*time.Hour), 10); err != nil || expired != 0 {
+		t.Errorf("ExpireGrants() again = %d, %v, want 0", expired, err)
+	}
+	wantBalance(t, pointsRepo, 50)
+}
+
+func TestApplyLocksBalance(t *testing.T) {
+	ctx := context.Background()
+	pointsRepo := NewPointsRepo(testdb.Open(t))
+	grantPoints(t, pointsRepo, 100, time.Now().Add(time.Hour))
+
+	// Parallel redemptions of 30 out of 100 points: three succeed
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := pointsRepo.Apply(ctx, &PointsChange{UserId: pointsUserId, Kind: model.PointsRedeem, Points: -30, IdempotencyKey: fmt.Sprintf("order-%d", i)})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	redeemed := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			redeemed++
+		case !apperror.HasReason(err, apperror.ReasonInsufficientPoints):
+			t.Errorf("Apply() = %v, want nil or %s", err, apperror.ReasonInsufficientPoints)
+		}
+	}
+	if redeemed != 3 {
+		t.Errorf("%d redemptions succeeded, want 3", redeemed)
+	}
+	wantBalance(t, pointsRepo, 10)
+}
+
+func grantPoints(t *testing.T, pointsRepo *pointsRepo, points int64, expiresAt time.Time) *model.PointsTransaction {
+	t.Helper()
+	txn, err := pointsRepo.Apply(context.Background(), &PointsChange{UserId: pointsUserId, Kind: model.PointsEarn, Points: points, ExpiresAt: expiresAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return txn
+}
+
+func wantBalance(t *testing.T, pointsRepo *pointsRepo, want int64) {
+	t.Helper()
+	balance, _, err := pointsRepo.GetBalance(context.Background(), pointsUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance != want {
+		t.Errorf("balance = %d, want %d", balance, want)
+	}
+}
+
+// Checks the unspent points of each grant, by transaction ID
+func wantRemaining(t *testing.T, pointsRepo *pointsRepo, want map[uint64]int64) {
+	t.Helper()
+	for id, remaining := range want {
+		grant := &model.PointsTransaction{}
+		if err := pointsRepo.db.Where("id = ?", id).First(grant).Error; err != nil {
+			t.Fatal(err)
+		}
+		if grant.Remaining != remaining {
+			t.Errorf("grant %d has %d points left, want %d", id, grant.Remaining, remaining)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type preferencesRepo struct {
+	db *gorm.DB
+}
+
+func NewPreferencesRepo(db *gorm.DB) *preferencesRepo {
+	return &preferencesRepo{
+		db: db,
+	}
+}
+
+// Returns the user's preferences, the defaults when they never set any
+func (preferencesRepo *preferencesRepo) Get(ctx context.Context, userId uint64) (*model.Preferences, error) {
+	preferences := &model.Preferences{}
+	err := preferencesRepo.db.WithContext(ctx).Where("user_id = ?", userId).First(preferences).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return DefaultPreferences(userId), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
+
+// Applies update to the user's preferences and saves them with the next
+// version. Fails with VERSION_MISMATCH unless expectedVersion, when not 0, is
+// the current version.
+func (preferencesRepo *preferencesRepo) Update(ctx context.Context, userId, expectedVersion uint64, update func(*model.Preferences)) (*model.Preferences, error) {
+	var preferences *model.Preferences
+
+	err := preferencesRepo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serialises the first update, when there is no row to lock yet
+		if err := lockUser(tx, userId); err != nil {
+			return err
+		}
+
+		preferences = &model.Preferences{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userId).First(preferences).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			preferences = DefaultPreferences(userId)
+		} else if err != nil {
+			return err
+		}
+
+		if expectedVersion != 0 && expectedVersion != preferences.Version {
+			return apperror.Aborted(apperror.ReasonVersionMismatch, "Preferences were modified by another request, fetch them again and retry").
+				WithMetadata("current_version", strconv.FormatUint(preferences.Version, 10))
+		}
+
+		update(preferences)
+		preferences.Version++
+		return tx.Save(preferences).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// Returns the preferences of a user who never set any
+func DefaultPreferences(userId uint64) *model.Preferences {
+	defaults := config.AppConfig.Preferences
+	return &model.Preferences{
+		UserId:          userId,
+		Language:        defaults.DefaultLanguage,
+		DistanceUnit:    defaults.DefaultDistanceUnit,
+		StreakReminders: true,
+	}
+}
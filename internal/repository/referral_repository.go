@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type referralRepo struct {
+	db *gorm.DB
+}
+
+func NewReferralRepo(db *gorm.DB) *referralRepo {
+	return &referralRepo{
+		db: db,
+	}
+}
+
+func (referralRepo *referralRepo) Create(ctx context.Context, referral *model.Referral) error {
+	return referralRepo.db.WithContext(ctx).Create(referral).Error
+}
+
+// Counts the other users who signed up on a device
+func (referralRepo *referralRepo) CountDeviceSignups(ctx context.Context, deviceId string, exceptUserId uint64) (int64, error) {
+	var count int64
+	err := referralRepo.db.WithContext(ctx).Model(&model.User{}).
+		Where("signup_device_id = ? AND id <> ?", deviceId, exceptUserId).
+		Count(&count).Error
+	return count, err
+}
+
+// Counts the referrals a phone number was already referred with
+func (referralRepo *referralRepo) CountByPhone(ctx context.Context, phoneNumber string) (int64, error) {
+	var count int64
+	err := referralRepo.db.WithContext(ctx).Model(&model.Referral{}).
+		Where("referee_phone = ?", phoneNumber).
+		Count(&count).Error
+	return count, err
+}
+
+// Counts the referrer's referrals that are pending or rewarded
+func (referralRepo *referralRepo) CountAccepted(ctx context.Context, referrerId uint64) (int64, error) {
+	var count int64
+	err := referralRepo.db.WithContext(ctx).Model(&model.Referral{}).
+		Where("referrer_id = ? AND status IN ?", referrerId, []string{model.ReferralPending, model.ReferralRewarded}).
+		Count(&count).Error
+	return count, err
+}
+
+// Rewards the pending referral of a user, crediting both sides in the same
+// transaction. Returns nil when the user has no pending referral.
+func (referralRepo *referralRepo) Reward(ctx context.Context, refereeId uint64, referrerPoints, refereePoints int64, expiresAt time.Time) (*model.Referral, error) {
+	var referral *model.Referral
+
+	err := referralRepo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		pending := &model.Referral{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("referee_id = ? AND status = ?", refereeId, model.ReferralPending).
+			First(pending).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		credits := []*PointsChange{
+			{UserId: pending.ReferrerId, Points: referrerPoints, Description: "Referral reward"},
+			{UserId: pending.RefereeId, Points: refereePoints, Description: "Welcome reward for joining with a referral"},
+		}
+		for _, credit := range credits {
+			if credit.Points == 0 {
+				continue
+			}
+			credit.Kind = model.PointsEarn
+			// Keys are unique per user, so both sides can share one
+			credit.IdempotencyKey = "referral:" + strconv.FormatUint(pending.Id, 10)
+			credit.ExpiresAt = expiresAt
+			if _, err := applyPointsChange(tx, credit); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		err = tx.Model(pending).Updates(map[string]interface{}{
+			"status":          model.ReferralRewarded,
+			"referrer_points": referrerPoints,
+			"referee_points":  refereePoints,
+			"rewarded_at":     now,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		pending.Status = model.ReferralRewarded
+		pending.ReferrerPoints = referrerPoints
+		pending.RefereePoints = refereePoints
+		pending.RewardedAt = &now
+		referral = pending
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return referral, nil
+}
+
+// Sums up the referrals a user made
+func (referralRepo *referralRepo) Stats(ctx context.Context, referrerId uint64) (*model.ReferralStats, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+		Points int64
+	}
+	err := referralRepo.db.WithContext(ctx).Model(&model.Referral{}).
+		Select("status, COUNT(*) AS count, SUM(referrer_points) AS points").
+		Where("referrer_id = ?", referrerId).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &model.ReferralStats{}
+	for _, row := range rows {
+		switch row.Status {
+		case model.ReferralPending:
+			stats.Pending = row.Count
+		case model.ReferralRewarded:
+			stats.Rewarded = row.Count
+			stats.PointsEarned = row.Points
+		case model.ReferralRejected:
+			stats.Rejected = row.Count
+		}
+	}
+
+	return stats, nil
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 
 	// "github.com/go-redis/redis"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
 	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -23,7 +24,7 @@ func NewUserRepo(db *gorm.DB) *userRepo {
 
 func (userRepo *userRepo) SignUp(ctx context.Context, data *model.SignUpUserData) error {
 	if err := userRepo.db.Create(&data).Error; err != nil {
-		return err
+		return translateUserError(err)
 	}
 
 	return nil
@@ -34,77 +35,210 @@ func (userRepo *userRepo) LogIn(ctx context.Context, data *model.LogInUserData)
 	
 	if err := userRepo.db.Where("phone_number = ?", data.PhoneNumber).First(&user).Error; err != nil {
 		log.Println("Failed to get user by phone_number:", err.Error())
-		return nil, errors.New("Invalid Phone Number or Password")
+		return nil, apperror.Unauthenticated(apperror.ReasonInvalidCredentials, "Invalid Phone Number or Password")
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(data.Password)); err != nil {
 		log.Println("Failed to compare password:", err.Error())
-		return nil, errors.New("Invalid Phone Number or Password")
+		return nil, apperror.Unauthenticated(apperror.ReasonInvalidCredentials, "Invalid Phone Number or Password")
 	}
 
 	return &user, nil
 }
 
-func (userRepo *userRepo) ForgotPassword(ctx context.Context, data *model.ChangePasswordUserData, email string) error {
-	if err := userRepo.db.Where("email = ?", email).Updates(&data).Error; err != nil {
+// Sets the password of a user who proved they own the account's email
+func (userRepo *userRepo) ResetPassword(ctx context.Context, data *model.ChangePasswordUserData, id uint64) error {
+	if _, err := userRepo.updateVersioned(ctx, id, 0, map[string]interface{}{"password": data.NewPassword}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (userRepo *userRepo) UpdateUser(ctx context.Context, data *model.UpdateUserData, id uint64) error {
-	if err := userRepo.db.Where("id = ?", id).Updates(&data).Error; err != nil {
+// Updates only the given columns, so empty values are written rather than
+// skipped, and returns the new version of the user
+func (userRepo *userRepo) UpdateUser(ctx context.Context, data *model.UpdateUserData, id uint64, fields []string, expectedVersion uint64) (uint64, error) {
+	values := map[string]interface{}{}
+	for _, field := range fields {
+		switch field {
+		case "name":
+			values["name"] = data.Name
+		case "phone_number":
+			values["phone_number"] = data.PhoneNumber
+		case "email":
+			values["email"] = data.Email
+		case "time_zone":
+			values["time_zone"] = data.TimeZone
+		case "city":
+			values["city"] = data.City
+		}
+	}
+
+	return userRepo.updateVersioned(ctx, id, expectedVersion, values)
+}
+
+// Fails with AlreadyExists when another user has value as their email or phone_number
+func (userRepo *userRepo) CheckContactAvailable(ctx context.Context, field, value string, id uint64) error {
+	var count int64
+	if err := userRepo.db.Model(&model.User{}).Where(field+" = ? AND id <> ?", value, id).Count(&count).Error; err != nil {
 		return err
 	}
+	if count > 0 {
+		return contactTakenError(field)
+	}
 
 	return nil
 }
 
 func (userRepo *userRepo) GetUser(ctx context.Context, data *model.User) error {
 	if err := userRepo.db.First(&data).Error; err != nil {
-		return err
+		return translateUserError(err)
 	}
 
 	return nil
 }
 
+func (userRepo *userRepo) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	var user model.User
+	if err := userRepo.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, translateUserError(err)
+	}
+
+	return &user, nil
+}
+
+// Returns the user's referral code, storing the one generate returns when
+// they have none yet. A code already taken by another user is generated again.
+func (userRepo *userRepo) AssignReferralCode(ctx context.Context, id uint64, generate func() (string, error)) (string, error) {
+	const attempts = 5
+
+	for i := 0; i < attempts; i++ {
+		var user model.User
+		if err := userRepo.db.WithContext(ctx).Select("referral_code").Where("id = ?", id).First(&user).Error; err != nil {
+			return "", translateUserError(err)
+		}
+		if user.ReferralCode != nil {
+			return *user.ReferralCode, nil
+		}
+
+		code, err := generate()
+		if err != nil {
+			return "", err
+		}
+
+		// Only set when still empty, a concurrent call may have assigned one
+		err = userRepo.db.WithContext(ctx).Model(&model.User{}).
+			Where("id = ? AND referral_code IS NULL", id).
+			Update("referral_code", code).Error
+		if err != nil && !isDuplicateEntry(err) {
+			return "", err
+		}
+	}
+
+	return "", errors.New("failed to generate a unique referral code")
+}
+
+// Returns the user a referral code belongs to
+func (userRepo *userRepo) GetByReferralCode(ctx context.Context, code string) (*model.User, error) {
+	user := &model.User{}
+	if err := userRepo.db.WithContext(ctx).Where("referral_code = ?", code).First(user).Error; err != nil {
+		return nil, translateUserError(err)
+	}
+	return user, nil
+}
+
+// Puts back the email or phone number a confirmed change replaced, only while
+// the user still has the value the change set, and increments the version
+func (userRepo *userRepo) RevertContact(ctx context.Context, id uint64, kind, newValue, oldValue string) error {
+	result := userRepo.db.WithContext(ctx).Model(&model.User{}).
+		Where("id = ?", id).
+		Where(map[string]interface{}{kind: newValue}).
+		Updates(map[string]interface{}{kind: oldValue, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return translateUserError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperror.FailedPrecondition(apperror.ReasonContactChanged, "The account has changed since, this link no longer applies")
+	}
+
+	return nil
+}
+
+// Opts the user out of the leaderboards, or back in, and returns the new version
+func (userRepo *userRepo) SetLeaderboardOptOut(ctx context.Context, id uint64, optOut bool, expectedVersion uint64) (uint64, error) {
+	return userRepo.updateVersioned(ctx, id, expectedVersion, map[string]interface{}{"leaderboard_opt_out": optOut})
+}
+
+// Returns the names of the given users by ID, skipping users that do not exist
+func (userRepo *userRepo) ListNames(ctx context.Context, ids []uint64) (map[uint64]string, error) {
+	var users []model.User
+	if err := userRepo.db.WithContext(ctx).Select("id", "name").Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	names := make(map[uint64]string, len(users))
+	for _, user := range users {
+		names[user.Id] = user.Name
+	}
+	return names, nil
+}
+
 func (userRepo *userRepo) ChangePassword(ctx context.Context, data *model.ChangePasswordUserData, oldPassword string, id uint64) error {
 	var user model.User
 	
 	if err := userRepo.db.Where("id = ?", id).First(&user).Error; err != nil {
 		log.Println("Failed to get user by id:", err.Error())
-		return errors.New("Invalid Id")
+		return translateUserError(err)
 	}
 
 	// Check oldPassword
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(oldPassword)); err != nil {
 		log.Println("Failed to compare password:", err.Error())
-		return errors.New("Invalid Password")
+		return apperror.InvalidArgument("Invalid Password", apperror.FieldViolation{Field: "old_password", Description: "does not match the current password"}).
+			WithReason(apperror.ReasonIncorrectPassword)
 	}
 
 	// Change password
-	if err := userRepo.db.Where("id = ?", id).Updates(&data).Error; err != nil {
+	if _, err := userRepo.updateVersioned(ctx, id, 0, map[string]interface{}{"password": data.NewPassword}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (userRepo *userRepo) UpdateDistanceTravelled(ctx context.Context, data *model.UpdateDistanceUserData, id uint64) error {
-	var user model.User;
-
-	// Retrieving user to confirm existence
-	if err := userRepo.db.Where("id = ?", id).First(&user).Error; err != nil {
-		log.Println("Failed to get user by id:", err.Error())
-		return errors.New("Invalid Id")
-	}
-
-	data.Distance += user.DistanceTravelled
-
-	if err := userRepo.db.Where("id = ?", id).Updates(&data).Error; err != nil {
-		return err
+// Applies values to the user and increments its version, in one statement
+// that only matches expectedVersion unless it is 0. Returns the new version.
+func (userRepo *userRepo) updateVersioned(ctx context.Context, id uint64, expectedVersion uint64, values map[string]interface{}) (uint64, error) {
+	var version uint64
+
+	err := userRepo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		values["version"] = gorm.Expr("version + 1")
+
+		query := tx.Model(&model.User{}).Where("id = ?", id)
+		if expectedVersion != 0 {
+			query = query.Where("version = ?", expectedVersion)
+		}
+
+		result := query.Updates(values)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// The updated row stays locked until commit, so this reads our own version
+		var user model.User
+		if err := tx.Select("version").Where("id = ?", id).First(&user).Error; err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return VersionMismatchError(user.Version)
+		}
+
+		version = user.Version
+		return nil
+	})
+	if err != nil {
+		return 0, translateUserError(err)
 	}
 
-	return nil
+	return version, nil
 }
\ No newline at end of file
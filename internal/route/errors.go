@@ -0,0 +1,85 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorBody is the JSON body of every failed REST call
+type errorBody struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code            string            `json:"code"`
+	Message         string            `json:"message"`
+	Reason          string            `json:"reason,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	FieldViolations []fieldViolation  `json:"field_violations,omitempty"`
+}
+
+type fieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// Writes err as a JSON error body with the HTTP status matching its gRPC
+// code, flattening the ErrorInfo and BadRequest details
+func writeError(c *gin.Context, err error) {
+	st := status.Convert(err)
+
+	detail := errorDetail{
+		Code:    code.Code_name[int32(st.Code())],
+		Message: st.Message(),
+	}
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			detail.Reason = d.Reason
+			detail.Metadata = d.Metadata
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				detail.FieldViolations = append(detail.FieldViolations, fieldViolation{Field: v.Field, Description: v.Description})
+			}
+		}
+	}
+
+	c.JSON(httpStatus(st.Code()), errorBody{Error: detail})
+}
+
+// Same mapping as grpc-gateway
+func httpStatus(c codes.Code) int {
+	switch c {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,231 @@
+package route
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+)
+
+// gateway turns REST calls into calls on the gRPC service implementation,
+// going through the same interceptors as the gRPC server.
+type gateway struct {
+	server       interface{}
+	interceptors []grpc.UnaryServerInterceptor
+	// Largest body read, larger ones are rejected
+	maxBodyBytes int64
+}
+
+// rpcHandler is the Gin handler calling one RPC
+type rpcHandler struct {
+	fullMethod string
+	// Request fields filled from the URL or the token rather than the body
+	boundFields []string
+	// Whether the RPC returns a file the route sends as the response body
+	download bool
+	handler  gin.HandlerFunc
+}
+
+// fileResponse is a response carrying a file, downloaded as is over REST
+type fileResponse interface {
+	proto.Message
+	GetFilename() string
+	GetContentType() string
+	GetContent() []byte
+}
+
+// binder fills one request field from the parts of the HTTP request that are not in the body
+type binder struct {
+	field string
+	bind  func(c *gin.Context) (uint64, error)
+}
+
+// rpc builds a Gin handler that decodes the JSON body into a fresh request
+// message, applies the binders and invokes method as fullMethod.
+func rpc[Req proto.Message, Res proto.Message](g *gateway, fullMethod string, newReq func() Req, method func(context.Context, Req) (Res, error), binders ...binder) rpcHandler {
+	return newRPCHandler(g, fullMethod, newReq, method, writeJSON, binders)
+}
+
+// rpcFile is rpc for RPCs returning a file, which is sent as an attachment
+// instead of the JSON form of the response
+func rpcFile[Req proto.Message, Res fileResponse](g *gateway, fullMethod string, newReq func() Req, method func(context.Context, Req) (Res, error), binders ...binder) rpcHandler {
+	h := newRPCHandler(g, fullMethod, newReq, method, writeFile, binders)
+	h.download = true
+	return h
+}
+
+func newRPCHandler[Req proto.Message, Res proto.Message](g *gateway, fullMethod string, newReq func() Req, method func(context.Context, Req) (Res, error), respond func(*gin.Context, Res), binders []binder) rpcHandler {
+	h := rpcHandler{fullMethod: fullMethod}
+	for _, b := range binders {
+		h.boundFields = append(h.boundFields, b.field)
+	}
+
+	h.handler = func(c *gin.Context) {
+		req := newReq()
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, g.maxBodyBytes))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(c, apperror.InvalidArgument(fmt.Sprintf("Request body is larger than %d bytes", maxBytesErr.Limit)).
+				WithReason(apperror.ReasonRequestTooLarge))
+			return
+		}
+		if err != nil {
+			writeError(c, apperror.InvalidArgument("Failed to read request body"))
+			return
+		}
+		// Requests without a body, such as GETs, carry their fields in the query string
+		if len(body) == 0 && c.Request.URL.RawQuery != "" {
+			if body, err = queryJSON(c, req); err != nil {
+				writeError(c, err)
+				return
+			}
+		}
+		if len(body) > 0 {
+			if err := unmarshalOptions.Unmarshal(body, req); err != nil {
+				writeError(c, apperror.InvalidArgument("Invalid JSON body: "+err.Error()))
+				return
+			}
+		}
+
+		for _, b := range binders {
+			value, err := b.bind(c)
+			if err != nil {
+				writeError(c, err)
+				return
+			}
+			fd := req.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(b.field))
+			req.ProtoReflect().Set(fd, protoreflect.ValueOfUint64(value))
+		}
+
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return method(ctx, req.(Req))
+		}
+		info := &grpc.UnaryServerInfo{Server: g.server, FullMethod: fullMethod}
+
+		res, err := g.chain(handler, info)(incomingContext(c), req)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		respond(c, res.(Res))
+	}
+
+	return h
+}
+
+func writeJSON[Res proto.Message](c *gin.Context, res Res) {
+	out, err := marshalOptions.Marshal(res)
+	if err != nil {
+		writeError(c, apperror.Internal(err))
+		return
+	}
+	c.Data(http.StatusOK, "application/json", out)
+}
+
+func writeFile[Res fileResponse](c *gin.Context, res Res) {
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": res.GetFilename()}))
+	c.Data(http.StatusOK, res.GetContentType(), res.GetContent())
+}
+
+// Turns the query string into the JSON form of req. Values stay strings,
+// which protojson accepts for numbers and well-known types, except booleans.
+func queryJSON(c *gin.Context, req proto.Message) ([]byte, error) {
+	fields := req.ProtoReflect().Descriptor().Fields()
+	values := map[string]interface{}{}
+
+	for name, value := range c.Request.URL.Query() {
+		fd := fields.ByName(protoreflect.Name(name))
+		if fd == nil {
+			fd = fields.ByJSONName(name)
+		}
+		if fd == nil || fd.IsList() || fd.IsMap() {
+			continue
+		}
+
+		if fd.Kind() == protoreflect.BoolKind {
+			b, err := strconv.ParseBool(value[0])
+			if err != nil {
+				return nil, apperror.InvalidArgument("Invalid query parameter "+name,
+					apperror.FieldViolation{Field: string(fd.Name()), Description: "must be true or false"})
+			}
+			values[string(fd.Name())] = b
+			continue
+		}
+		values[string(fd.Name())] = value[0]
+	}
+
+	return json.Marshal(values)
+}
+
+// Wraps handler with the interceptors, the first one being the outermost
+func (g *gateway) chain(handler grpc.UnaryHandler, info *grpc.UnaryServerInfo) func(context.Context, interface{}) (interface{}, error) {
+	for i := len(g.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := g.interceptors[i], handler
+		handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+	return handler
+}
+
+// Exposes the Authorization header as gRPC metadata so the gRPC
+// interceptors authenticate REST calls exactly like gRPC calls
+func incomingContext(c *gin.Context) context.Context {
+	md := metadata.MD{}
+	if authorization := c.GetHeader("Authorization"); authorization != "" {
+		md.Set("authorization", authorization)
+	}
+	return metadata.NewIncomingContext(c.Request.Context(), md)
+}
+
+// Rejects requests to protected routes that carry no bearer token
+func requireAuth(c *gin.Context) {
+	if !strings.HasPrefix(c.GetHeader("Authorization"), "Bearer ") {
+		writeError(c, apperror.Unauthenticated(apperror.ReasonInvalidToken, "Authorization header with a Bearer token is required"))
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// Sets the uint64 request field of the same name from a path parameter
+func pathParam(name string) binder {
+	return binder{field: name, bind: func(c *gin.Context) (uint64, error) {
+		value, err := strconv.ParseUint(c.Param(name), 10, 64)
+		if err != nil || value == 0 {
+			return 0, apperror.InvalidArgument("Invalid "+name+" in path",
+				apperror.FieldViolation{Field: name, Description: "must be a positive integer"})
+		}
+		return value, nil
+	}}
+}
+
+// Sets a request field to the ID of the authenticated caller, for routes
+// acting on the caller's own account
+func callerId(field string) binder {
+	return binder{field: field, bind: func(c *gin.Context) (uint64, error) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		return service.AuthenticateToken(c.Request.Context(), token)
+	}}
+}
@@ -1 +1,197 @@
-package route
\ No newline at end of file
+package route
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/health"
+	"google.golang.org/grpc"
+)
+
+// endpoint maps one REST route onto a UserService RPC
+type endpoint struct {
+	method string
+	path   string
+	auth   bool
+	rpc    rpcHandler
+}
+
+// Endpoint describes a REST route for the API documentation
+type Endpoint struct {
+	Method     string
+	Path       string // OpenAPI style, e.g. /v1/users/{id}
+	FullMethod string
+	Auth       bool
+	// Request fields taken from the path or the token instead of the body
+	BoundFields []string
+	// Whether the response is a file download rather than JSON
+	Download bool
+}
+
+// Lists the REST routes with the RPC each of them calls
+func Endpoints() []Endpoint {
+	var endpoints []Endpoint
+	for _, e := range userEndpoints(&gateway{}, pb.UnimplementedUserServiceServer{}) {
+		endpoints = append(endpoints, Endpoint{
+			Method:      e.method,
+			Path:        openAPIPath("/v1" + e.path),
+			FullMethod:  e.rpc.fullMethod,
+			Auth:        e.auth,
+			BoundFields: e.rpc.boundFields,
+			Download:    e.rpc.download,
+		})
+	}
+	return endpoints
+}
+
+// Registers the REST mirror of every UserService RPC but UpdateDistanceTravelled,
+// which only the trip service calls over gRPC. The interceptors are the ones
+// installed on the gRPC server so both transports share auth.
+func RegisterUserRoutes(r *gin.Engine, server pb.UserServiceServer, interceptors ...grpc.UnaryServerInterceptor) {
+	g := &gateway{server: server, interceptors: interceptors, maxBodyBytes: config.AppConfig.HTTPMaxBodyBytes}
+
+	v1 := r.Group("/v1")
+	for _, e := range userEndpoints(g, server) {
+		handlers := []gin.HandlerFunc{e.rpc.handler}
+		if e.auth {
+			handlers = append([]gin.HandlerFunc{requireAuth}, handlers...)
+		}
+		v1.Handle(e.method, e.path, handlers...)
+	}
+}
+
+func userEndpoints(g *gateway, s pb.UserServiceServer) []endpoint {
+	return []endpoint{
+		{http.MethodPost, "/auth/signup", false, rpc(g, pb.UserService_SignUp_FullMethodName,
+			func() *pb.SignUpRequest { return &pb.SignUpRequest{} }, s.SignUp)},
+		{http.MethodPost, "/auth/login", false, rpc(g, pb.UserService_LogIn_FullMethodName,
+			func() *pb.LogInRequest { return &pb.LogInRequest{} }, s.LogIn)},
+		{http.MethodPost, "/auth/logout", true, rpc(g, pb.UserService_LogOut_FullMethodName,
+			func() *pb.LogOutRequest { return &pb.LogOutRequest{} }, s.LogOut, callerId("id"))},
+		{http.MethodPost, "/auth/forgot-password", false, rpc(g, pb.UserService_ForgotPassword_FullMethodName,
+			func() *pb.ForgotPasswordRequest { return &pb.ForgotPasswordRequest{} }, s.ForgotPassword)},
+		{http.MethodPost, "/auth/reset-password", false, rpc(g, pb.UserService_ResetPassword_FullMethodName,
+			func() *pb.ResetPasswordRequest { return &pb.ResetPasswordRequest{} }, s.ResetPassword)},
+		{http.MethodPost, "/auth/refresh", false, rpc(g, pb.UserService_RefreshToken_FullMethodName,
+			func() *pb.RefreshTokenRequest { return &pb.RefreshTokenRequest{} }, s.RefreshToken)},
+		{http.MethodPost, "/auth/revert-contact-change", false, rpc(g, pb.UserService_RevertContactChange_FullMethodName,
+			func() *pb.RevertContactChangeRequest { return &pb.RevertContactChangeRequest{} }, s.RevertContactChange)},
+		{http.MethodPost, "/auth/authenticate", false, rpc(g, pb.UserService_AuthenticateUser_FullMethodName,
+			func() *pb.AuthenticateUserRequest { return &pb.AuthenticateUserRequest{} }, s.AuthenticateUser)},
+		{http.MethodGet, "/users/:id", true, rpc(g, pb.UserService_GetUser_FullMethodName,
+			func() *pb.GetUserRequest { return &pb.GetUserRequest{} }, s.GetUser, pathParam("id"))},
+		{http.MethodPatch, "/users/:id", true, rpc(g, pb.UserService_UpdateUser_FullMethodName,
+			func() *pb.UpdateUserRequest { return &pb.UpdateUserRequest{} }, s.UpdateUser, pathParam("id"))},
+		{http.MethodPost, "/users/:id/email", true, rpc(g, pb.UserService_RequestEmailChange_FullMethodName,
+			func() *pb.RequestEmailChangeRequest { return &pb.RequestEmailChangeRequest{} }, s.RequestEmailChange, pathParam("id"))},
+		{http.MethodPost, "/users/:id/email/confirm", true, rpc(g, pb.UserService_ConfirmEmailChange_FullMethodName,
+			func() *pb.ConfirmEmailChangeRequest { return &pb.ConfirmEmailChangeRequest{} }, s.ConfirmEmailChange, pathParam("id"))},
+		{http.MethodPost, "/users/:id/phone", true, rpc(g, pb.UserService_RequestPhoneChange_FullMethodName,
+			func() *pb.RequestPhoneChangeRequest { return &pb.RequestPhoneChangeRequest{} }, s.RequestPhoneChange, pathParam("id"))},
+		{http.MethodPost, "/users/:id/phone/confirm", true, rpc(g, pb.UserService_ConfirmPhoneChange_FullMethodName,
+			func() *pb.ConfirmPhoneChangeRequest { return &pb.ConfirmPhoneChangeRequest{} }, s.ConfirmPhoneChange, pathParam("id"))},
+		{http.MethodPost, "/users/:id/password", true, rpc(g, pb.UserService_ChangePassword_FullMethodName,
+			func() *pb.ChangePasswordRequest { return &pb.ChangePasswordRequest{} }, s.ChangePassword, pathParam("id"))},
+		{http.MethodGet, "/users/:id/distance/entries", true, rpc(g, pb.UserService_ListDistanceEntries_FullMethodName,
+			func() *pb.ListDistanceEntriesRequest { return &pb.ListDistanceEntriesRequest{} }, s.ListDistanceEntries, pathParam("id"))},
+		{http.MethodPost, "/users/:id/distance/adjustments", true, rpc(g, pb.UserService_AdjustDistance_FullMethodName,
+			func() *pb.AdjustDistanceRequest { return &pb.AdjustDistanceRequest{} }, s.AdjustDistance, pathParam("id"))},
+		{http.MethodGet, "/users/:id/eco-impact", true, rpc(g, pb.UserService_GetEcoImpact_FullMethodName,
+			func() *pb.GetEcoImpactRequest { return &pb.GetEcoImpactRequest{} }, s.GetEcoImpact, pathParam("id"))},
+		{http.MethodGet, "/users/:id/points", true, rpc(g, pb.UserService_GetPointsBalance_FullMethodName,
+			func() *pb.GetPointsBalanceRequest { return &pb.GetPointsBalanceRequest{} }, s.GetPointsBalance, pathParam("id"))},
+		{http.MethodGet, "/users/:id/points/transactions", true, rpc(g, pb.UserService_ListPointsTransactions_FullMethodName,
+			func() *pb.ListPointsTransactionsRequest { return &pb.ListPointsTransactionsRequest{} }, s.ListPointsTransactions, pathParam("id"))},
+		{http.MethodPost, "/users/:id/points/redemptions", true, rpc(g, pb.UserService_RedeemPoints_FullMethodName,
+			func() *pb.RedeemPointsRequest { return &pb.RedeemPointsRequest{} }, s.RedeemPoints, pathParam("id"))},
+		{http.MethodPost, "/users/:id/points/adjustments", true, rpc(g, pb.UserService_AdjustPoints_FullMethodName,
+			func() *pb.AdjustPointsRequest { return &pb.AdjustPointsRequest{} }, s.AdjustPoints, pathParam("id"))},
+		{http.MethodGet, "/users/:id/membership", true, rpc(g, pb.UserService_GetMembership_FullMethodName,
+			func() *pb.GetMembershipRequest { return &pb.GetMembershipRequest{} }, s.GetMembership, pathParam("id"))},
+		{http.MethodGet, "/leaderboard", true, rpc(g, pb.UserService_GetLeaderboard_FullMethodName,
+			func() *pb.GetLeaderboardRequest { return &pb.GetLeaderboardRequest{} }, s.GetLeaderboard)},
+		{http.MethodGet, "/users/:id/leaderboard/rank", true, rpc(g, pb.UserService_GetMyRank_FullMethodName,
+			func() *pb.GetMyRankRequest { return &pb.GetMyRankRequest{} }, s.GetMyRank, pathParam("id"))},
+		{http.MethodPut, "/users/:id/leaderboard/opt-out", true, rpc(g, pb.UserService_SetLeaderboardOptOut_FullMethodName,
+			func() *pb.SetLeaderboardOptOutRequest { return &pb.SetLeaderboardOptOutRequest{} }, s.SetLeaderboardOptOut, pathParam("id"))},
+		{http.MethodGet, "/badges", true, rpc(g, pb.UserService_ListBadges_FullMethodName,
+			func() *pb.ListBadgesRequest { return &pb.ListBadgesRequest{} }, s.ListBadges)},
+		{http.MethodGet, "/users/:id/badges", true, rpc(g, pb.UserService_ListMyBadges_FullMethodName,
+			func() *pb.ListMyBadgesRequest { return &pb.ListMyBadgesRequest{} }, s.ListMyBadges, pathParam("id"))},
+		{http.MethodPut, "/users/:id/goal", true, rpc(g, pb.UserService_SetGoal_FullMethodName,
+			func() *pb.SetGoalRequest { return &pb.SetGoalRequest{} }, s.SetGoal, pathParam("id"))},
+		{http.MethodGet, "/users/:id/goal", true, rpc(g, pb.UserService_GetGoalProgress_FullMethodName,
+			func() *pb.GetGoalProgressRequest { return &pb.GetGoalProgressRequest{} }, s.GetGoalProgress, pathParam("id"))},
+		{http.MethodGet, "/users/:id/referrals", true, rpc(g, pb.UserService_GetReferralStats_FullMethodName,
+			func() *pb.GetReferralStatsRequest { return &pb.GetReferralStatsRequest{} }, s.GetReferralStats, pathParam("id"))},
+		{http.MethodGet, "/users/:id/eco-report", true, rpcFile(g, pb.UserService_GenerateEcoReport_FullMethodName,
+			func() *pb.GenerateEcoReportRequest { return &pb.GenerateEcoReportRequest{} }, s.GenerateEcoReport, pathParam("id"))},
+		{http.MethodPost, "/users/:id/places", true, rpc(g, pb.UserService_CreateSavedPlace_FullMethodName,
+			func() *pb.CreateSavedPlaceRequest { return &pb.CreateSavedPlaceRequest{} }, s.CreateSavedPlace, pathParam("id"))},
+		{http.MethodGet, "/users/:id/places", true, rpc(g, pb.UserService_ListSavedPlaces_FullMethodName,
+			func() *pb.ListSavedPlacesRequest { return &pb.ListSavedPlacesRequest{} }, s.ListSavedPlaces, pathParam("id"))},
+		{http.MethodPatch, "/users/:id/places/:place_id", true, rpc(g, pb.UserService_UpdateSavedPlace_FullMethodName,
+			func() *pb.UpdateSavedPlaceRequest { return &pb.UpdateSavedPlaceRequest{} }, s.UpdateSavedPlace, pathParam("id"), pathParam("place_id"))},
+		{http.MethodDelete, "/users/:id/places/:place_id", true, rpc(g, pb.UserService_DeleteSavedPlace_FullMethodName,
+			func() *pb.DeleteSavedPlaceRequest { return &pb.DeleteSavedPlaceRequest{} }, s.DeleteSavedPlace, pathParam("id"), pathParam("place_id"))},
+		{http.MethodPost, "/users/:id/emergency-contacts", true, rpc(g, pb.UserService_CreateEmergencyContact_FullMethodName,
+			func() *pb.CreateEmergencyContactRequest { return &pb.CreateEmergencyContactRequest{} }, s.CreateEmergencyContact, pathParam("id"))},
+		{http.MethodGet, "/users/:id/emergency-contacts", true, rpc(g, pb.UserService_ListEmergencyContacts_FullMethodName,
+			func() *pb.ListEmergencyContactsRequest { return &pb.ListEmergencyContactsRequest{} }, s.ListEmergencyContacts, pathParam("id"))},
+		{http.MethodPatch, "/users/:id/emergency-contacts/:contact_id", true, rpc(g, pb.UserService_UpdateEmergencyContact_FullMethodName,
+			func() *pb.UpdateEmergencyContactRequest { return &pb.UpdateEmergencyContactRequest{} }, s.UpdateEmergencyContact, pathParam("id"), pathParam("contact_id"))},
+		{http.MethodDelete, "/users/:id/emergency-contacts/:contact_id", true, rpc(g, pb.UserService_DeleteEmergencyContact_FullMethodName,
+			func() *pb.DeleteEmergencyContactRequest { return &pb.DeleteEmergencyContactRequest{} }, s.DeleteEmergencyContact, pathParam("id"), pathParam("contact_id"))},
+		{http.MethodPost, "/users/:id/emergency-contacts/:contact_id/verify", true, rpc(g, pb.UserService_VerifyEmergencyContact_FullMethodName,
+			func() *pb.VerifyEmergencyContactRequest { return &pb.VerifyEmergencyContactRequest{} }, s.VerifyEmergencyContact, pathParam("id"), pathParam("contact_id"))},
+		{http.MethodPost, "/users/:id/emergency-contacts/:contact_id/resend", true, rpc(g, pb.UserService_ResendEmergencyContactCode_FullMethodName,
+			func() *pb.ResendEmergencyContactCodeRequest { return &pb.ResendEmergencyContactCodeRequest{} }, s.ResendEmergencyContactCode, pathParam("id"), pathParam("contact_id"))},
+		{http.MethodGet, "/users/:id/safety-profile", true, rpc(g, pb.UserService_GetSafetyProfile_FullMethodName,
+			func() *pb.GetSafetyProfileRequest { return &pb.GetSafetyProfileRequest{} }, s.GetSafetyProfile, pathParam("id"))},
+		{http.MethodGet, "/users/:id/preferences", true, rpc(g, pb.UserService_GetPreferences_FullMethodName,
+			func() *pb.GetPreferencesRequest { return &pb.GetPreferencesRequest{} }, s.GetPreferences, pathParam("id"))},
+		{http.MethodPatch, "/users/:id/preferences", true, rpc(g, pb.UserService_UpdatePreferences_FullMethodName,
+			func() *pb.UpdatePreferencesRequest { return &pb.UpdatePreferencesRequest{} }, s.UpdatePreferences, pathParam("id"))},
+	}
+}
+
+// Turns Gin path parameters (:id) into OpenAPI ones ({id})
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Registers the liveness and readiness probes used by the deployment
+func RegisterHealthRoutes(r *gin.Engine, checker *health.Checker) {
+	// Liveness only tells whether the process can still answer HTTP requests
+	r.GET("/healthz", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+		})
+	})
+
+	// Readiness fails while a dependency is down or the service is shutting down
+	r.GET("/readyz", func(c *gin.Context) {
+		ready, dependencies := checker.Ready()
+
+		status := http.StatusOK
+		state := "ready"
+		if !ready {
+			status = http.StatusServiceUnavailable
+			state = "not ready"
+		}
+
+		c.JSON(status, gin.H{
+			"status":       state,
+			"dependencies": dependencies,
+		})
+	})
+}
@@ -1 +1,43 @@
-package route
\ No newline at end of file
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/docs"
+)
+
+// Swagger UI is loaded from a CDN so no assets need to be bundled
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <title>EcoTaxi User Service API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: "/swagger/doc.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`
+
+// Serves the embedded OpenAPI document and a Swagger UI page to explore it
+func RegisterSwaggerRoutes(r *gin.Engine) {
+	r.GET("/swagger/doc.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", docs.SwaggerJSON)
+	})
+
+	r.GET("/swagger/doc.yaml", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/yaml", docs.SwaggerYAML)
+	})
+
+	r.GET("/swagger", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+	})
+}
@@ -0,0 +1,11 @@
+-- Irreversible once numbers from outside the default region (SG, +65) are
+-- stored: they have no 8 digit local form. The scalar subquery returns more
+-- than one row in that case, failing the migration before anything changes.
+DO (SELECT 1 UNION ALL SELECT 1 FROM users WHERE phone_number NOT LIKE '+65%' LIMIT 2);
+
+-- Only +65 numbers fit back into the old local format
+UPDATE users
+SET phone_number = SUBSTRING(phone_number, 4)
+WHERE phone_number LIKE '+65%';
+
+ALTER TABLE users MODIFY phone_number VARCHAR(8) NOT NULL;
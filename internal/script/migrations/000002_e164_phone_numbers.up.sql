@@ -0,0 +1,7 @@
+-- Phone numbers are stored in E.164 format (e.g. +6591234567), up to 15 digits plus the "+"
+ALTER TABLE users MODIFY phone_number VARCHAR(16) NOT NULL;
+
+-- Existing rows hold 8 digit local numbers from the default region (SG, +65)
+UPDATE users
+SET phone_number = CONCAT('+65', REPLACE(REPLACE(phone_number, ' ', ''), '-', ''))
+WHERE phone_number NOT LIKE '+%';
@@ -0,0 +1,2 @@
+-- Incremented by every write to the row, for optimistic concurrency control
+ALTER TABLE users ADD COLUMN version BIGINT UNSIGNED NOT NULL DEFAULT 1;
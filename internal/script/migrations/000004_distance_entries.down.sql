@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS distance_entries;
+
+ALTER TABLE users MODIFY distance_travelled FLOAT DEFAULT 0;
@@ -0,0 +1,15 @@
+-- FLOAT loses precision once totals grow, DOUBLE matches the float64 in the service
+ALTER TABLE users MODIFY distance_travelled DOUBLE NOT NULL DEFAULT 0;
+
+-- One row per trip, the unique trip_id makes recording a trip idempotent
+CREATE TABLE distance_entries (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    trip_id VARCHAR(64) NOT NULL,
+    distance DOUBLE NOT NULL,
+    total_after DOUBLE NOT NULL,
+    version_after BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME(3) NOT NULL,
+    UNIQUE KEY idx_distance_entries_trip_id (trip_id),
+    KEY idx_distance_entries_user_id (user_id)
+);
@@ -0,0 +1,11 @@
+DELETE FROM distance_entries WHERE kind = 'adjustment';
+
+ALTER TABLE distance_entries
+    DROP KEY idx_distance_entries_user_created,
+    ADD KEY idx_distance_entries_user_id (user_id),
+    DROP COLUMN adjusted_by,
+    DROP COLUMN reason,
+    DROP COLUMN kind,
+    MODIFY trip_id VARCHAR(64) NOT NULL;
+
+ALTER TABLE users DROP COLUMN role;
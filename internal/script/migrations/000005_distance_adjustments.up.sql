@@ -0,0 +1,20 @@
+-- Admins are granted by setting role = 'admin' directly in the database
+ALTER TABLE users ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'user';
+
+-- Adjustments are not tied to a trip, several NULL trip IDs do not break the unique key
+ALTER TABLE distance_entries
+    MODIFY trip_id VARCHAR(64) NULL,
+    ADD COLUMN kind VARCHAR(16) NOT NULL DEFAULT 'trip' AFTER user_id,
+    ADD COLUMN reason VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN adjusted_by BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    DROP KEY idx_distance_entries_user_id,
+    ADD KEY idx_distance_entries_user_created (user_id, created_at);
+
+-- Users whose distance predates the ledger get an opening entry, so every
+-- total can be rebuilt by summing the user's entries
+INSERT INTO distance_entries (user_id, kind, distance, total_after, version_after, reason, created_at)
+SELECT u.id, 'adjustment', u.distance_travelled - COALESCE(SUM(e.distance), 0), u.distance_travelled, u.version, 'Opening balance', NOW(3)
+FROM users u
+LEFT JOIN distance_entries e ON e.user_id = u.id
+GROUP BY u.id, u.distance_travelled, u.version
+HAVING u.distance_travelled - COALESCE(SUM(e.distance), 0) <> 0;
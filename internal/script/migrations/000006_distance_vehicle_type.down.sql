@@ -0,0 +1 @@
+ALTER TABLE distance_entries DROP COLUMN vehicle_type;
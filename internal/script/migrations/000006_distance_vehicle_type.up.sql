@@ -0,0 +1,2 @@
+-- Vehicle the distance was travelled in, empty when unknown
+ALTER TABLE distance_entries ADD COLUMN vehicle_type VARCHAR(16) NOT NULL DEFAULT '' AFTER trip_id;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS points_balances;
+DROP TABLE IF EXISTS points_transactions;
+
+ALTER TABLE distance_entries DROP COLUMN points_earned;
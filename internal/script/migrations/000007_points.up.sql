@@ -0,0 +1,24 @@
+ALTER TABLE distance_entries ADD COLUMN points_earned BIGINT NOT NULL DEFAULT 0 AFTER version_after;
+
+CREATE TABLE points_transactions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    points BIGINT NOT NULL,
+    balance_after BIGINT NOT NULL,
+    -- Unspent part of the points added by the row, spent oldest expiry first
+    remaining BIGINT NOT NULL DEFAULT 0,
+    expires_at DATETIME(3) NULL,
+    idempotency_key VARCHAR(80) NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_by BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    created_at DATETIME(3) NOT NULL,
+    UNIQUE KEY idx_points_transactions_user_key (user_id, idempotency_key),
+    KEY idx_points_transactions_expires_at (expires_at)
+);
+
+CREATE TABLE points_balances (
+    user_id BIGINT UNSIGNED PRIMARY KEY,
+    balance BIGINT NOT NULL DEFAULT 0,
+    updated_at DATETIME(3) NOT NULL
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tier_changes;
+DROP TABLE IF EXISTS memberships;
@@ -0,0 +1,19 @@
+CREATE TABLE memberships (
+    user_id BIGINT UNSIGNED PRIMARY KEY,
+    tier VARCHAR(16) NOT NULL DEFAULT 'seedling',
+    -- Set while the user no longer qualifies for their tier
+    downgrade_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NOT NULL,
+    KEY idx_memberships_downgrade_at (downgrade_at)
+);
+
+CREATE TABLE tier_changes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    from_tier VARCHAR(16) NOT NULL,
+    to_tier VARCHAR(16) NOT NULL,
+    rolling_distance DOUBLE NOT NULL,
+    rolling_trips BIGINT NOT NULL,
+    created_at DATETIME(3) NOT NULL,
+    KEY idx_tier_changes_user_id (user_id)
+);
@@ -0,0 +1,2 @@
+-- Users who opted out are left off the leaderboards kept in Redis
+ALTER TABLE users ADD COLUMN leaderboard_opt_out BOOLEAN NOT NULL DEFAULT FALSE;
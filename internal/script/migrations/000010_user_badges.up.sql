@@ -0,0 +1,8 @@
+-- Badges are defined in the configuration, only awards are stored
+CREATE TABLE user_badges (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    badge_code VARCHAR(64) NOT NULL,
+    awarded_at DATETIME(3) NOT NULL,
+    UNIQUE KEY idx_user_badges_user_badge (user_id, badge_code)
+);
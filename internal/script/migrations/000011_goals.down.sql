@@ -0,0 +1,5 @@
+ALTER TABLE distance_entries DROP KEY idx_distance_entries_kind_created;
+
+DROP TABLE IF EXISTS goals;
+
+ALTER TABLE users DROP COLUMN time_zone;
@@ -0,0 +1,17 @@
+-- IANA time zone days, months and streaks are counted in; empty for the service's one
+ALTER TABLE users ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE TABLE goals (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    -- YYYY-MM, in the user's time zone
+    month CHAR(7) NOT NULL,
+    metric VARCHAR(16) NOT NULL,
+    target DOUBLE NOT NULL,
+    created_at DATETIME(3) NOT NULL,
+    updated_at DATETIME(3) NOT NULL,
+    UNIQUE KEY idx_goals_user_month (user_id, month)
+);
+
+-- Streak reminders look for every user's recent trips
+ALTER TABLE distance_entries ADD KEY idx_distance_entries_kind_created (kind, created_at);
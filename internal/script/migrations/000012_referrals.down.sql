@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS referrals;
+
+ALTER TABLE users
+    DROP KEY idx_users_signup_device_id,
+    DROP KEY idx_users_referral_code,
+    DROP COLUMN signup_device_id,
+    DROP COLUMN referral_code;
@@ -0,0 +1,23 @@
+-- Codes are assigned on sign up, or on first use for existing users
+ALTER TABLE users
+    ADD COLUMN referral_code VARCHAR(16) NULL,
+    ADD COLUMN signup_device_id VARCHAR(64) NOT NULL DEFAULT '',
+    ADD UNIQUE KEY idx_users_referral_code (referral_code),
+    ADD KEY idx_users_signup_device_id (signup_device_id);
+
+CREATE TABLE referrals (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    referrer_id BIGINT UNSIGNED NOT NULL,
+    referee_id BIGINT UNSIGNED NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    reject_reason VARCHAR(32) NOT NULL DEFAULT '',
+    referee_phone VARCHAR(16) NOT NULL,
+    device_id VARCHAR(64) NOT NULL DEFAULT '',
+    referrer_points BIGINT NOT NULL DEFAULT 0,
+    referee_points BIGINT NOT NULL DEFAULT 0,
+    created_at DATETIME(3) NOT NULL,
+    rewarded_at DATETIME(3) NULL,
+    UNIQUE KEY idx_referrals_referee_id (referee_id),
+    KEY idx_referrals_referrer_id (referrer_id),
+    KEY idx_referrals_referee_phone (referee_phone)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE emergency_contacts (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    phone_number VARCHAR(16) NOT NULL,
+    relationship VARCHAR(32) NOT NULL DEFAULT '',
+    -- Set once the contact confirms the code sent to their phone
+    verified_at DATETIME(3) NULL,
+    created_at DATETIME(3) NOT NULL,
+    updated_at DATETIME(3) NOT NULL,
+    UNIQUE KEY idx_emergency_contacts_user_phone (user_id, phone_number)
+);
@@ -0,0 +1,16 @@
+-- Users without a row have the configured defaults
+CREATE TABLE user_preferences (
+    user_id BIGINT UNSIGNED PRIMARY KEY,
+    version BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    language VARCHAR(8) NOT NULL,
+    distance_unit VARCHAR(8) NOT NULL,
+    quiet_ride BOOLEAN NOT NULL DEFAULT FALSE,
+    ev_only BOOLEAN NOT NULL DEFAULT FALSE,
+    wheelchair_accessible BOOLEAN NOT NULL DEFAULT FALSE,
+    service_animal BOOLEAN NOT NULL DEFAULT FALSE,
+    hearing_impaired BOOLEAN NOT NULL DEFAULT FALSE,
+    streak_reminders BOOLEAN NOT NULL DEFAULT TRUE,
+    marketing_emails BOOLEAN NOT NULL DEFAULT FALSE,
+    marketing_sms BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at DATETIME(3) NOT NULL
+);
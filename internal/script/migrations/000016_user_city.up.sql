@@ -0,0 +1,2 @@
+-- City the user is ranked in on the city leaderboards, empty for none
+ALTER TABLE users ADD COLUMN city VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"log"
+	"strings"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type contextKey string
+
+const (
+	userIdContextKey  contextKey = "user_id"
+	serviceContextKey contextKey = "service"
+)
+
+// RPCs callable without any credentials
+var publicMethods = map[string]bool{
+	pb.UserService_SignUp_FullMethodName:              true,
+	pb.UserService_LogIn_FullMethodName:               true,
+	pb.UserService_ForgotPassword_FullMethodName:      true,
+	pb.UserService_ResetPassword_FullMethodName:       true,
+	pb.UserService_RefreshToken_FullMethodName:        true,
+	pb.UserService_RevertContactChange_FullMethodName: true,
+	pb.UserService_AuthenticateUser_FullMethodName:    true,
+}
+
+// Returns a context carrying the ID of the authenticated caller
+func ContextWithUserId(ctx context.Context, userId uint64) context.Context {
+	return context.WithValue(ctx, userIdContextKey, userId)
+}
+
+// Returns the ID of the authenticated caller, if the request carried a token
+func UserIdFromContext(ctx context.Context) (uint64, bool) {
+	userId, ok := ctx.Value(userIdContextKey).(uint64)
+	return userId, ok
+}
+
+// Returns a context marking the caller as a trusted internal service
+func ContextWithService(ctx context.Context) context.Context {
+	return context.WithValue(ctx, serviceContextKey, true)
+}
+
+// Reports whether the request carried the internal service token
+func isServiceCaller(ctx context.Context) bool {
+	service, _ := ctx.Value(serviceContextKey).(bool)
+	return service
+}
+
+// Verifies an access token and returns the ID of the user it was issued to
+func AuthenticateToken(ctx context.Context, token string) (uint64, error) {
+	parsedId, err := ParseToken(token, config.AppConfig.JWT.Secret, TokenTypeAccess)
+	if err != nil {
+		return 0, apperror.Unauthenticated(apperror.ReasonInvalidToken, err.Error())
+	}
+
+	user := &model.User{}
+	if err := config.DB.WithContext(ctx).Model(&model.User{}).Where("id = ?", parsedId).First(user).Error; err != nil {
+		log.Println("Failed to get user for token:", err.Error())
+		return 0, apperror.Unauthenticated(apperror.ReasonInvalidToken, "Invalid credentials")
+	}
+
+	return user.Id, nil
+}
+
+// Authenticates the caller and stores it in the context: internal services
+// with the service token in the "x-service-token" metadata, users with a
+// bearer token in the "authorization" metadata. Only public methods can be
+// called without either.
+func AuthUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if serviceToken := md.Get("x-service-token"); len(serviceToken) > 0 {
+		expected := config.AppConfig.GRPC.ServiceToken
+		if expected == "" || subtle.ConstantTimeCompare([]byte(serviceToken[0]), []byte(expected)) != 1 {
+			return nil, apperror.Unauthenticated(apperror.ReasonInvalidToken, "Invalid service token")
+		}
+		return handler(ContextWithService(ctx), req)
+	}
+
+	if len(md.Get("authorization")) == 0 {
+		if publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		return nil, apperror.Unauthenticated(apperror.ReasonInvalidToken, "Authorization with a Bearer token is required")
+	}
+
+	token, found := strings.CutPrefix(md.Get("authorization")[0], "Bearer ")
+	if !found || token == "" {
+		return nil, apperror.Unauthenticated(apperror.ReasonInvalidToken, "Authorization must be a Bearer token")
+	}
+
+	userId, err := AuthenticateToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ContextWithUserId(ctx, userId), req)
+}
+
+// Lets internal services and the owner of the account through
+func checkUserAccess(ctx context.Context, id uint64) error {
+	if isServiceCaller(ctx) {
+		return nil
+	}
+
+	callerId, ok := UserIdFromContext(ctx)
+	if !ok {
+		return apperror.Unauthenticated(apperror.ReasonInvalidToken, "Authorization with a Bearer token is required")
+	}
+	if callerId != id {
+		return apperror.PermissionDenied(apperror.ReasonNotAccountOwner, "Permission denied")
+	}
+	return nil
+}
+
+// Rejects every caller but internal services
+func requireService(ctx context.Context) error {
+	if isServiceCaller(ctx) {
+		return nil
+	}
+	if _, ok := UserIdFromContext(ctx); !ok {
+		return apperror.Unauthenticated(apperror.ReasonInvalidToken, "Only internal services can do this")
+	}
+	return apperror.PermissionDenied(apperror.ReasonServiceRequired, "Only internal services can do this")
+}
+
+// Returns the ID of the authenticated caller when they are an admin
+func requireAdmin(ctx context.Context) (uint64, error) {
+	callerId, ok := UserIdFromContext(ctx)
+	if !ok {
+		return 0, apperror.Unauthenticated(apperror.ReasonInvalidToken, "Authorization with a Bearer token is required")
+	}
+
+	user := &model.User{}
+	if err := config.DB.WithContext(ctx).Select("id", "role").Where("id = ?", callerId).First(user).Error; err != nil {
+		return 0, err
+	}
+	if user.Role != model.RoleAdmin {
+		return 0, apperror.PermissionDenied(apperror.ReasonAdminRequired, "Only admins can do this")
+	}
+
+	return callerId, nil
+}
+
+// Like checkUserAccess, but also lets admins act on any account
+func checkUserOrAdminAccess(ctx context.Context, id uint64) error {
+	if err := checkUserAccess(ctx, id); err != nil {
+		if _, adminErr := requireAdmin(ctx); adminErr != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/badge"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *UserServiceServer) ListBadges(ctx context.Context, req *pb.ListBadgesRequest) (*pb.ListBadgesResponse, error) {
+	res := &pb.ListBadgesResponse{}
+	for _, definition := range config.AppConfig.Badges.Definitions {
+		res.Badges = append(res.Badges, badgeToPB(definition))
+	}
+	return res, nil
+}
+
+func (s *UserServiceServer) ListMyBadges(ctx context.Context, req *pb.ListMyBadgesRequest) (*pb.ListMyBadgesResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	userRepo := repository.NewUserRepo(config.DB)
+	if err := userRepo.GetUser(ctx, &model.User{Id: req.Id}); err != nil {
+		return nil, err
+	}
+
+	// Evaluating first awards badges a missed evaluation did not
+	metrics, badges, err := badge.Evaluate(ctx, req.Id, time.Now())
+	if err != nil {
+		log.Println("Failed to evaluate badges:", err.Error())
+		return nil, err
+	}
+
+	awardedAt := make(map[string]time.Time, len(badges))
+	for _, b := range badges {
+		awardedAt[b.BadgeCode] = b.AwardedAt
+	}
+
+	res := &pb.ListMyBadgesResponse{}
+	for _, definition := range config.AppConfig.Badges.Definitions {
+		userBadge := &pb.UserBadge{
+			Badge:    badgeToPB(definition),
+			Progress: metrics[definition.Metric],
+		}
+		if at, ok := awardedAt[definition.Code]; ok {
+			userBadge.Earned = true
+			userBadge.AwardedAt = timestamppb.New(at)
+		}
+		res.Badges = append(res.Badges, userBadge)
+	}
+
+	return res, nil
+}
+
+// Awards the badges a new ledger entry earns, logging failures
+func evaluateBadges(ctx context.Context, userId uint64) {
+	if _, _, err := badge.Evaluate(ctx, userId, time.Now()); err != nil {
+		log.Println("Failed to evaluate badges:", err.Error())
+	}
+}
+
+func badgeToPB(definition config.BadgeDefinition) *pb.Badge {
+	res := &pb.Badge{
+		Code:        definition.Code,
+		Name:        definition.Name,
+		Description: definition.Description,
+		Threshold:   definition.Threshold,
+	}
+	switch definition.Metric {
+	case config.BadgeMetricDistance:
+		res.Metric = pb.BadgeMetric_BADGE_METRIC_DISTANCE
+	case config.BadgeMetricTrips:
+		res.Metric = pb.BadgeMetric_BADGE_METRIC_TRIPS
+	case config.BadgeMetricCO2Saved:
+		res.Metric = pb.BadgeMetric_BADGE_METRIC_CO2_SAVED
+	case config.BadgeMetricStreakDays:
+		res.Metric = pb.BadgeMetric_BADGE_METRIC_STREAK_DAYS
+	}
+	return res
+}
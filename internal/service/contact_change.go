@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/cache"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/email"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+// Size in bytes of the tokens in revert links
+const revertTokenSize = 32
+
+// Kinds of contact changes, named after the users column they update
+const (
+	contactEmail       = "email"
+	contactPhoneNumber = "phone_number"
+)
+
+func (s *UserServiceServer) RequestEmailChange(ctx context.Context, req *pb.RequestEmailChangeRequest) (*pb.RequestEmailChangeResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	user, err := getUserForContactChange(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpectedVersion != 0 && req.ExpectedVersion != user.Version {
+		return nil, repository.VersionMismatchError(user.Version)
+	}
+
+	if err := requestContactChange(ctx, user, contactEmail, req.Email, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+
+	return &pb.RequestEmailChangeResponse{Message: "Verification code sent to the new email"}, nil
+}
+
+func (s *UserServiceServer) ConfirmEmailChange(ctx context.Context, req *pb.ConfirmEmailChangeRequest) (*pb.ConfirmEmailChangeResponse, error) {
+	if err := confirmContactChange(ctx, req.Id, contactEmail, req.Code); err != nil {
+		return nil, err
+	}
+	return &pb.ConfirmEmailChangeResponse{Message: "Email updated successfully!"}, nil
+}
+
+func (s *UserServiceServer) RequestPhoneChange(ctx context.Context, req *pb.RequestPhoneChangeRequest) (*pb.RequestPhoneChangeResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	phoneNumber, err := normalizePhoneNumber("phone_number", req.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := getUserForContactChange(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpectedVersion != 0 && req.ExpectedVersion != user.Version {
+		return nil, repository.VersionMismatchError(user.Version)
+	}
+
+	if err := requestContactChange(ctx, user, contactPhoneNumber, phoneNumber, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+
+	return &pb.RequestPhoneChangeResponse{Message: "Verification code sent to the new phone number"}, nil
+}
+
+func (s *UserServiceServer) ConfirmPhoneChange(ctx context.Context, req *pb.ConfirmPhoneChangeRequest) (*pb.ConfirmPhoneChangeResponse, error) {
+	if err := confirmContactChange(ctx, req.Id, contactPhoneNumber, req.Code); err != nil {
+		return nil, err
+	}
+	return &pb.ConfirmPhoneChangeResponse{Message: "Phone number updated successfully!"}, nil
+}
+
+// Restores the email or phone number a confirmed change replaced and signs
+// the user out everywhere, in case the change was made by someone else
+func (s *UserServiceServer) RevertContactChange(ctx context.Context, req *pb.RevertContactChangeRequest) (*pb.RevertContactChangeResponse, error) {
+	verificationCache := cache.NewVerificationCache(config.Redis)
+
+	tokenHash := hashCode(req.Token)
+
+	revert, err := verificationCache.GetRevert(ctx, tokenHash)
+	if errors.Is(err, redis.Nil) {
+		return nil, apperror.FailedPrecondition(apperror.ReasonInvalidToken, "The link is invalid or has expired")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	userRepo := repository.NewUserRepo(config.DB)
+
+	// Only applies while the user still has the value being undone, so the
+	// link stays usable when the previous value cannot be restored yet
+	if err := userRepo.RevertContact(ctx, revert.UserId, revert.Kind, revert.NewValue, revert.OldValue); err != nil {
+		log.Println("Failed to revert contact change:", err.Error())
+		return nil, err
+	}
+
+	// Reverted, the link must not work twice
+	if err := verificationCache.DeleteRevert(ctx, tokenHash); err != nil {
+		log.Println("Failed to delete revert token:", err.Error())
+	}
+
+	if err := verificationCache.DeletePendingChange(ctx, revert.UserId, revert.Kind); err != nil {
+		log.Println("Failed to delete pending change:", err.Error())
+	}
+
+	sessionCache := cache.NewSessionCache(config.Redis)
+	if err := sessionCache.DeleteRefreshToken(ctx, revert.UserId); err != nil {
+		log.Println("Failed to sign out user after revert:", err.Error())
+		return nil, err
+	}
+
+	return &pb.RevertContactChangeResponse{Message: "Change reverted, please log in again and change your password"}, nil
+}
+
+func getUserForContactChange(ctx context.Context, id uint64) (*model.User, error) {
+	user := &model.User{Id: id}
+	userRepo := repository.NewUserRepo(config.DB)
+
+	if err := userRepo.GetUser(ctx, user); err != nil {
+		log.Println("Failed to get user:", err.Error())
+		return nil, err
+	}
+	return user, nil
+}
+
+// Stores the new value until it is confirmed and sends the code to it. The
+// change is only applied while the user is still at expectedVersion, unless 0.
+func requestContactChange(ctx context.Context, user *model.User, kind, value string, expectedVersion uint64) error {
+	if currentContact(user, kind) == value {
+		return apperror.InvalidArgument("Nothing to change",
+			apperror.FieldViolation{Field: kind, Description: "is the current value"})
+	}
+
+	// Checked again on confirmation, this only spares the user a useless code
+	userRepo := repository.NewUserRepo(config.DB)
+	if err := userRepo.CheckContactAvailable(ctx, kind, value, user.Id); err != nil {
+		return err
+	}
+
+	code, err := utils.GenerateCode(config.VerificationCodeLength)
+	if err != nil {
+		return err
+	}
+
+	verification := config.AppConfig.Verification
+	verificationCache := cache.NewVerificationCache(config.Redis)
+
+	if err := verificationCache.StorePendingChange(ctx, user.Id, kind, value, hashCode(code), expectedVersion, verification.CodeTTL); err != nil {
+		log.Println("Failed to store pending change:", err.Error())
+		return err
+	}
+
+	minutes := int(verification.CodeTTL.Minutes())
+	switch kind {
+	case contactEmail:
+		data := map[string]interface{}{"Name": user.Name, "Code": code, "Minutes": minutes}
+		err = email.Send(value, userLanguage(ctx, user.Id), email.ConfirmEmailChange, data)
+	case contactPhoneNumber:
+		err = utils.SendSMS(value, fmt.Sprintf("Your EcoTaxi verification code is %s. It expires in %d minutes.", code, minutes))
+	}
+	if err != nil {
+		log.Println("Failed to send verification code:", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Applies a pending change once its code matches and tells the previous
+// contact how to undo it
+func confirmContactChange(ctx context.Context, id uint64, kind, code string) error {
+	if err := checkUserAccess(ctx, id); err != nil {
+		return err
+	}
+
+	verificationCache := cache.NewVerificationCache(config.Redis)
+
+	pending, err := verificationCache.CheckPendingChange(ctx, id, kind, hashCode(code), config.AppConfig.Verification.MaxAttempts)
+	switch {
+	case errors.Is(err, redis.Nil):
+		return apperror.FailedPrecondition(apperror.ReasonNoPendingChange, "There is no pending change to confirm, or it has expired")
+	case errors.Is(err, cache.ErrInvalidCode):
+		return apperror.InvalidArgument("Invalid verification code",
+			apperror.FieldViolation{Field: "code", Description: "does not match the code that was sent"}).
+			WithReason(apperror.ReasonInvalidCode)
+	case errors.Is(err, cache.ErrTooManyAttempts):
+		return apperror.ResourceExhausted(apperror.ReasonTooManyAttempts, "Too many invalid codes, please request the change again")
+	case err != nil:
+		return err
+	}
+
+	user, err := getUserForContactChange(ctx, id)
+	if err != nil {
+		return err
+	}
+	oldValue := currentContact(user, kind)
+	value := pending.Value
+
+	userRepo := repository.NewUserRepo(config.DB)
+
+	// The value may have been registered by someone else since the code was sent
+	if err := userRepo.CheckContactAvailable(ctx, kind, value, id); err != nil {
+		return err
+	}
+
+	// The unique indexes still reject a value taken in the meantime, and the
+	// version one the user changed since the request
+	version, err := userRepo.UpdateUser(ctx, contactUpdateData(kind, value), id, []string{kind}, pending.ExpectedVersion)
+	if err != nil {
+		log.Println("Failed to confirm contact change:", err.Error())
+		return err
+	}
+
+	if err := verificationCache.DeletePendingChange(ctx, id, kind); err != nil {
+		log.Println("Failed to delete pending change:", err.Error())
+	}
+
+	// Email and phone number requested together expect the same version, this
+	// change must not keep the other one from being confirmed
+	if pending.ExpectedVersion != 0 {
+		if err := verificationCache.RebasePendingChange(ctx, id, otherContact(kind), pending.ExpectedVersion, version); err != nil {
+			log.Println("Failed to update pending change:", err.Error())
+		}
+	}
+
+	// The change is applied, a failed notice must not report it as failed
+	if err := notifyContactChanged(ctx, user, kind, oldValue, value); err != nil {
+		log.Println("Failed to notify previous contact:", err.Error())
+	}
+
+	return nil
+}
+
+// Sends the previous email or phone number a link undoing the change
+func notifyContactChanged(ctx context.Context, user *model.User, kind, oldValue, newValue string) error {
+	token, err := utils.GenerateSecret(revertTokenSize)
+	if err != nil {
+		return err
+	}
+
+	verification := config.AppConfig.Verification
+	verificationCache := cache.NewVerificationCache(config.Redis)
+
+	err = verificationCache.StoreRevert(ctx, hashCode(token), &cache.ContactRevert{
+		UserId:   user.Id,
+		Kind:     kind,
+		OldValue: oldValue,
+		NewValue: newValue,
+	}, verification.RevertTTL)
+	if err != nil {
+		return err
+	}
+
+	revertLink := config.AppConfig.FrontendURL + "/revert-contact-change?token=" + url.QueryEscape(token)
+	days := int(verification.RevertTTL.Hours() / 24)
+
+	switch kind {
+	case contactEmail:
+		data := map[string]interface{}{"Name": user.Name, "NewEmail": newValue, "Link": revertLink, "Days": days}
+		return email.Send(oldValue, userLanguage(ctx, user.Id), email.EmailChanged, data)
+	case contactPhoneNumber:
+		return utils.SendSMS(oldValue, fmt.Sprintf("The phone number of your EcoTaxi account was changed. If this wasn't you, undo it within %d days: %s", days, revertLink))
+	}
+	return nil
+}
+
+func currentContact(user *model.User, kind string) string {
+	if kind == contactPhoneNumber {
+		return user.PhoneNumber
+	}
+	return user.Email
+}
+
+func otherContact(kind string) string {
+	if kind == contactPhoneNumber {
+		return contactEmail
+	}
+	return contactPhoneNumber
+}
+
+// Hashes a verification code or revert token with the server's secret
+func hashCode(code string) string {
+	return utils.HashCode(code, config.AppConfig.Verification.Secret)
+}
+
+func contactUpdateData(kind, value string) *model.UpdateUserData {
+	if kind == contactPhoneNumber {
+		return &model.UpdateUserData{PhoneNumber: value}
+	}
+	return &model.UpdateUserData{Email: value}
+}
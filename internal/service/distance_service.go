@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"log"
+	"strconv"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+func (s *UserServiceServer) ListDistanceEntries(ctx context.Context, req *pb.ListDistanceEntriesRequest) (*pb.ListDistanceEntriesResponse, error) {
+	if err := checkUserOrAdminAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	beforeId, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &model.DistanceEntryFilter{
+		BeforeId: beforeId,
+		Limit:    pageSize(req.PageSize) + 1,
+	}
+	if req.StartTime != nil {
+		start := req.StartTime.AsTime()
+		filter.Start = &start
+	}
+	if req.EndTime != nil {
+		end := req.EndTime.AsTime()
+		filter.End = &end
+	}
+	if filter.Start != nil && filter.End != nil && !filter.End.After(*filter.Start) {
+		return nil, apperror.InvalidArgument("Invalid time range",
+			apperror.FieldViolation{Field: "end_time", Description: "must be after start_time"})
+	}
+
+	db := config.DB
+	distanceRepo := repository.NewDistanceRepo(db)
+
+	entries, err := distanceRepo.ListEntries(ctx, req.Id, filter)
+	if err != nil {
+		log.Println("Failed to list distance entries:", err.Error())
+		return nil, err
+	}
+
+	// The extra entry fetched only tells whether there is a next page
+	res := &pb.ListDistanceEntriesResponse{}
+	if len(entries) == filter.Limit {
+		entries = entries[:len(entries)-1]
+		res.NextPageToken = encodePageToken(entries[len(entries)-1].Id)
+	}
+	for i := range entries {
+		res.Entries = append(res.Entries, distanceEntryToPB(&entries[i]))
+	}
+
+	return res, nil
+}
+
+func (s *UserServiceServer) AdjustDistance(ctx context.Context, req *pb.AdjustDistanceRequest) (*pb.AdjustDistanceResponse, error) {
+	adminId, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db := config.DB
+	distanceRepo := repository.NewDistanceRepo(db)
+
+	entry, err := distanceRepo.Adjust(ctx, req.Id, req.Distance, req.Reason, adminId)
+	if err != nil {
+		log.Println("Failed to adjust distance:", err.Error())
+		return nil, err
+	}
+
+	recordOnLeaderboards(ctx, entry)
+	evaluateBadges(ctx, req.Id)
+	evaluateMembership(ctx, req.Id)
+
+	log.Printf("Admin %d adjusted distance of user %d by %g: %s", adminId, req.Id, req.Distance, req.Reason)
+
+	return &pb.AdjustDistanceResponse{
+		Entry:             distanceEntryToPB(entry),
+		DistanceTravelled: entry.TotalAfter,
+		Version:           entry.VersionAfter,
+	}, nil
+}
+
+func distanceEntryToPB(entry *model.DistanceEntry) *pb.DistanceEntry {
+	res := &pb.DistanceEntry{
+		Id:          entry.Id,
+		Kind:        pb.DistanceEntryKind_DISTANCE_ENTRY_KIND_TRIP,
+		Distance:    entry.Distance,
+		TotalAfter:  entry.TotalAfter,
+		Reason:      entry.Reason,
+		AdjustedBy:  entry.AdjustedBy,
+		CreatedAt:   timestamppb.New(entry.CreatedAt),
+		VehicleType: vehicleTypeToPB(entry.VehicleType),
+	}
+	if entry.Kind == model.DistanceEntryAdjustment {
+		res.Kind = pb.DistanceEntryKind_DISTANCE_ENTRY_KIND_ADJUSTMENT
+	}
+	if entry.TripId != nil {
+		res.TripId = *entry.TripId
+	}
+	return res
+}
+
+func pageSize(requested int32) int {
+	if requested <= 0 {
+		return defaultPageSize
+	}
+	if requested > maxPageSize {
+		return maxPageSize
+	}
+	return int(requested)
+}
+
+// Page tokens are the opaque form of the ID of the last entry of the previous page
+func encodePageToken(lastId uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(lastId, 10)))
+}
+
+func decodePageToken(token string) (uint64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		if id, err := strconv.ParseUint(string(raw), 10, 64); err == nil {
+			return id, nil
+		}
+	}
+
+	return 0, apperror.InvalidArgument("Invalid page token",
+		apperror.FieldViolation{Field: "page_token", Description: "must be a next_page_token returned by a previous call"})
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *UserServiceServer) GetEcoImpact(ctx context.Context, req *pb.GetEcoImpactRequest) (*pb.GetEcoImpactResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	var start, end *time.Time
+	if req.StartTime != nil {
+		t := req.StartTime.AsTime()
+		start = &t
+	}
+	if req.EndTime != nil {
+		t := req.EndTime.AsTime()
+		end = &t
+	}
+	if start != nil && end != nil && !end.After(*start) {
+		return nil, apperror.InvalidArgument("Invalid time range",
+			apperror.FieldViolation{Field: "end_time", Description: "must be after start_time"})
+	}
+
+	db := config.DB
+
+	user := model.User{Id: req.Id}
+	if err := repository.NewUserRepo(db).GetUser(ctx, &user); err != nil {
+		log.Println("Failed to get user:", err.Error())
+		return nil, err
+	}
+	loc := user.Location()
+
+	distanceRepo := repository.NewDistanceRepo(db)
+	days, err := distanceRepo.SumByDay(ctx, req.Id, loc, start, end)
+	if err != nil {
+		log.Println("Failed to sum distance:", err.Error())
+		return nil, err
+	}
+
+	emissions := config.AppConfig.Emissions
+	var totalDistance, totalSaved float64
+	res := &pb.GetEcoImpactResponse{}
+
+	// Days come oldest first, so each period is complete once a day falls past its end
+	var period *pb.EcoImpactPeriod
+	var periodDistance, periodSaved float64
+	for _, day := range days {
+		saved := day.Distance * emissions.SavedPerKm(day.Kind, day.VehicleType)
+		totalDistance += day.Distance
+		totalSaved += saved
+
+		// Days are dates in the user's time zone, periods start at their midnight
+		date := time.Date(day.Day.Year(), day.Day.Month(), day.Day.Day(), 0, 0, 0, 0, loc)
+		periodStart := startOfPeriod(date, req.Granularity)
+		if period == nil || !period.StartTime.AsTime().Equal(periodStart) {
+			if period != nil {
+				period.Impact = ecoImpact(periodDistance, periodSaved)
+				res.Periods = append(res.Periods, period)
+			}
+			period = &pb.EcoImpactPeriod{
+				StartTime: timestamppb.New(periodStart),
+				EndTime:   timestamppb.New(endOfPeriod(periodStart, req.Granularity)),
+			}
+			periodDistance, periodSaved = 0, 0
+		}
+		periodDistance += day.Distance
+		periodSaved += saved
+	}
+	if period != nil {
+		period.Impact = ecoImpact(periodDistance, periodSaved)
+		res.Periods = append(res.Periods, period)
+	}
+
+	res.Total = ecoImpact(totalDistance, totalSaved)
+	return res, nil
+}
+
+// Expresses grams of CO2 saved as trees and litres of petrol
+func ecoImpact(distance, co2Saved float64) *pb.EcoImpact {
+	emissions := config.AppConfig.Emissions
+	return &pb.EcoImpact{
+		Distance:        distance,
+		Co2SavedGrams:   co2Saved,
+		TreesEquivalent: co2Saved / emissions.TreeAbsorptionPerYear,
+		FuelSavedLitres: co2Saved / emissions.FuelEmissionsPerLitre,
+	}
+}
+
+// Returns the start of the day, week (from Monday), month or year containing t
+func startOfPeriod(t time.Time, granularity pb.Granularity) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch granularity {
+	case pb.Granularity_GRANULARITY_DAY:
+		return day
+	case pb.Granularity_GRANULARITY_WEEK:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case pb.Granularity_GRANULARITY_YEAR:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+}
+
+func endOfPeriod(start time.Time, granularity pb.Granularity) time.Time {
+	switch granularity {
+	case pb.Granularity_GRANULARITY_DAY:
+		return start.AddDate(0, 0, 1)
+	case pb.Granularity_GRANULARITY_WEEK:
+		return start.AddDate(0, 0, 7)
+	case pb.Granularity_GRANULARITY_YEAR:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+// Maps the proto vehicle type to the name used by the emissions model
+func vehicleTypeName(vehicleType pb.VehicleType) string {
+	switch vehicleType {
+	case pb.VehicleType_VEHICLE_TYPE_EV:
+		return config.VehicleTypeEV
+	case pb.VehicleType_VEHICLE_TYPE_HYBRID:
+		return config.VehicleTypeHybrid
+	case pb.VehicleType_VEHICLE_TYPE_SHARED_RIDE:
+		return config.VehicleTypeSharedRide
+	default:
+		return ""
+	}
+}
+
+func vehicleTypeToPB(vehicleType string) pb.VehicleType {
+	switch vehicleType {
+	case config.VehicleTypeEV:
+		return pb.VehicleType_VEHICLE_TYPE_EV
+	case config.VehicleTypeHybrid:
+		return pb.VehicleType_VEHICLE_TYPE_HYBRID
+	case config.VehicleTypeSharedRide:
+		return pb.VehicleType_VEHICLE_TYPE_SHARED_RIDE
+	default:
+		return pb.VehicleType_VEHICLE_TYPE_UNSPECIFIED
+	}
+}
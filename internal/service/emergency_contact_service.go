@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/cache"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/utils"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *UserServiceServer) CreateEmergencyContact(ctx context.Context, req *pb.CreateEmergencyContactRequest) (*pb.CreateEmergencyContactResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	phoneNumber, err := normalizePhoneNumber("phone_number", req.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := getUserForContactChange(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if phoneNumber == user.PhoneNumber {
+		return nil, apperror.InvalidArgument("Invalid emergency contact",
+			apperror.FieldViolation{Field: "phone_number", Description: "cannot be the user's own phone number"})
+	}
+
+	contact := &model.EmergencyContact{
+		UserId:       req.Id,
+		Name:         req.Name,
+		PhoneNumber:  phoneNumber,
+		Relationship: req.Relationship,
+	}
+
+	contactRepo := repository.NewEmergencyContactRepo(config.DB)
+	if err := contactRepo.Create(ctx, contact, config.AppConfig.Safety.MaxContacts); err != nil {
+		log.Println("Failed to create emergency contact:", err.Error())
+		return nil, err
+	}
+
+	// The contact is kept when the code cannot be sent, it can be resent
+	message := "Emergency contact added, enter the code sent to their phone to verify it"
+	if err := sendContactCode(ctx, user, contact); err != nil {
+		message = "Emergency contact added, but the verification code could not be sent, please resend it"
+	}
+
+	return &pb.CreateEmergencyContactResponse{Contact: contactToPB(contact), Message: message}, nil
+}
+
+func (s *UserServiceServer) ListEmergencyContacts(ctx context.Context, req *pb.ListEmergencyContactsRequest) (*pb.ListEmergencyContactsResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	contactRepo := repository.NewEmergencyContactRepo(config.DB)
+	contacts, err := contactRepo.List(ctx, req.Id, false)
+	if err != nil {
+		log.Println("Failed to list emergency contacts:", err.Error())
+		return nil, err
+	}
+
+	res := &pb.ListEmergencyContactsResponse{}
+	for i := range contacts {
+		res.Contacts = append(res.Contacts, contactToPB(&contacts[i]))
+	}
+	return res, nil
+}
+
+func (s *UserServiceServer) UpdateEmergencyContact(ctx context.Context, req *pb.UpdateEmergencyContactRequest) (*pb.UpdateEmergencyContactResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	fields, err := updateContactPaths(req)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := getUserForContactChange(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	contact := &model.EmergencyContact{
+		Id:           req.ContactId,
+		UserId:       req.Id,
+		Name:         req.Name,
+		Relationship: req.Relationship,
+	}
+	for _, field := range fields {
+		if field != "phone_number" {
+			continue
+		}
+		if contact.PhoneNumber, err = normalizePhoneNumber("phone_number", req.PhoneNumber); err != nil {
+			return nil, err
+		}
+		if contact.PhoneNumber == user.PhoneNumber {
+			return nil, apperror.InvalidArgument("Invalid emergency contact",
+				apperror.FieldViolation{Field: "phone_number", Description: "cannot be the user's own phone number"})
+		}
+	}
+
+	contactRepo := repository.NewEmergencyContactRepo(config.DB)
+	phoneChanged, err := contactRepo.Update(ctx, contact, fields)
+	if err != nil {
+		log.Println("Failed to update emergency contact:", err.Error())
+		return nil, err
+	}
+
+	message := "Emergency contact updated successfully!"
+	if phoneChanged {
+		message = "Emergency contact updated, enter the code sent to their phone to verify it"
+		if err := sendContactCode(ctx, user, contact); err != nil {
+			message = "Emergency contact updated, but the verification code could not be sent, please resend it"
+		}
+	}
+
+	return &pb.UpdateEmergencyContactResponse{Contact: contactToPB(contact), Message: message}, nil
+}
+
+func (s *UserServiceServer) DeleteEmergencyContact(ctx context.Context, req *pb.DeleteEmergencyContactRequest) (*pb.DeleteEmergencyContactResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	contactRepo := repository.NewEmergencyContactRepo(config.DB)
+	if err := contactRepo.Delete(ctx, req.Id, req.ContactId); err != nil {
+		log.Println("Failed to delete emergency contact:", err.Error())
+		return nil, err
+	}
+
+	verificationCache := cache.NewVerificationCache(config.Redis)
+	if err := verificationCache.DeletePendingChange(ctx, req.Id, contactVerificationKind(req.ContactId)); err != nil {
+		log.Println("Failed to delete pending contact verification:", err.Error())
+	}
+
+	return &pb.DeleteEmergencyContactResponse{Message: "Emergency contact deleted successfully!"}, nil
+}
+
+func (s *UserServiceServer) VerifyEmergencyContact(ctx context.Context, req *pb.VerifyEmergencyContactRequest) (*pb.VerifyEmergencyContactResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	contactRepo := repository.NewEmergencyContactRepo(config.DB)
+	contact, err := contactRepo.Get(ctx, req.Id, req.ContactId)
+	if err != nil {
+		return nil, err
+	}
+	if contact.VerifiedAt != nil {
+		return &pb.VerifyEmergencyContactResponse{Contact: contactToPB(contact)}, nil
+	}
+
+	kind := contactVerificationKind(contact.Id)
+	verificationCache := cache.NewVerificationCache(config.Redis)
+
+	pending, err := verificationCache.CheckPendingChange(ctx, req.Id, kind, hashCode(req.Code), config.AppConfig.Verification.MaxAttempts)
+	switch {
+	case errors.Is(err, redis.Nil):
+		return nil, apperror.FailedPrecondition(apperror.ReasonNoPendingChange, "There is no code to confirm, or it has expired, please resend it")
+	case errors.Is(err, cache.ErrInvalidCode):
+		return nil, apperror.InvalidArgument("Invalid verification code",
+			apperror.FieldViolation{Field: "code", Description: "does not match the code that was sent"}).
+			WithReason(apperror.ReasonInvalidCode)
+	case errors.Is(err, cache.ErrTooManyAttempts):
+		return nil, apperror.ResourceExhausted(apperror.ReasonTooManyAttempts, "Too many invalid codes, please resend the code")
+	case err != nil:
+		return nil, err
+	}
+
+	// A code sent to a previous phone number of the contact does not verify the current one
+	verified, err := contactRepo.MarkVerified(ctx, contact.Id, pending.Value)
+	if err != nil {
+		log.Println("Failed to verify emergency contact:", err.Error())
+		return nil, err
+	}
+	if !verified {
+		return nil, apperror.FailedPrecondition(apperror.ReasonNoPendingChange, "The contact's phone number changed since the code was sent, please resend it")
+	}
+
+	if err := verificationCache.DeletePendingChange(ctx, req.Id, kind); err != nil {
+		log.Println("Failed to delete pending contact verification:", err.Error())
+	}
+
+	if contact, err = contactRepo.Get(ctx, req.Id, contact.Id); err != nil {
+		return nil, err
+	}
+	return &pb.VerifyEmergencyContactResponse{Contact: contactToPB(contact)}, nil
+}
+
+func (s *UserServiceServer) ResendEmergencyContactCode(ctx context.Context, req *pb.ResendEmergencyContactCodeRequest) (*pb.ResendEmergencyContactCodeResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	contactRepo := repository.NewEmergencyContactRepo(config.DB)
+	contact, err := contactRepo.Get(ctx, req.Id, req.ContactId)
+	if err != nil {
+		return nil, err
+	}
+	if contact.VerifiedAt != nil {
+		return nil, apperror.FailedPrecondition(apperror.ReasonAlreadyVerified, "Emergency contact is already verified")
+	}
+
+	user, err := getUserForContactChange(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := sendContactCode(ctx, user, contact); err != nil {
+		return nil, err
+	}
+
+	return &pb.ResendEmergencyContactCodeResponse{Message: "Verification code sent to the contact's phone"}, nil
+}
+
+// Returns who to notify when the rider triggers an SOS, for the trip service
+func (s *UserServiceServer) GetSafetyProfile(ctx context.Context, req *pb.GetSafetyProfileRequest) (*pb.GetSafetyProfileResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	user, err := getUserForContactChange(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	contactRepo := repository.NewEmergencyContactRepo(config.DB)
+	contacts, err := contactRepo.List(ctx, req.Id, true)
+	if err != nil {
+		log.Println("Failed to list emergency contacts:", err.Error())
+		return nil, err
+	}
+
+	res := &pb.GetSafetyProfileResponse{
+		UserId:      user.Id,
+		Name:        user.Name,
+		PhoneNumber: user.PhoneNumber,
+	}
+	for i := range contacts {
+		res.Contacts = append(res.Contacts, contactToPB(&contacts[i]))
+	}
+	return res, nil
+}
+
+// Sends a code to the contact's phone, replacing any code sent before
+func sendContactCode(ctx context.Context, user *model.User, contact *model.EmergencyContact) error {
+	code, err := utils.GenerateCode(config.VerificationCodeLength)
+	if err != nil {
+		return err
+	}
+
+	verification := config.AppConfig.Verification
+	verificationCache := cache.NewVerificationCache(config.Redis)
+
+	kind := contactVerificationKind(contact.Id)
+	if err := verificationCache.StorePendingChange(ctx, user.Id, kind, contact.PhoneNumber, hashCode(code), 0, verification.CodeTTL); err != nil {
+		log.Println("Failed to store contact verification:", err.Error())
+		return err
+	}
+
+	body := fmt.Sprintf("%s added you as an emergency contact on EcoTaxi. If you agree, give them the code %s. It expires in %d minutes.",
+		user.Name, code, int(verification.CodeTTL.Minutes()))
+	if err := utils.SendSMS(contact.PhoneNumber, body); err != nil {
+		log.Println("Failed to send contact verification code:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// Kind of the pending verification of a contact, one per contact
+func contactVerificationKind(contactId uint64) string {
+	return "emergency_contact:" + strconv.FormatUint(contactId, 10)
+}
+
+// Returns the fields UpdateEmergencyContact has to change: the update_mask
+// paths, or every non-empty field when there is no mask. Masked fields cannot
+// be empty, except relationship, which is optional.
+func updateContactPaths(req *pb.UpdateEmergencyContactRequest) ([]string, error) {
+	values := map[string]string{
+		"name":         req.Name,
+		"phone_number": req.PhoneNumber,
+		"relationship": req.Relationship,
+	}
+
+	if len(req.UpdateMask.GetPaths()) == 0 {
+		var paths []string
+		for _, path := range []string{"name", "phone_number", "relationship"} {
+			if values[path] != "" {
+				paths = append(paths, path)
+			}
+		}
+		if len(paths) == 0 {
+			return nil, apperror.InvalidArgument("Nothing to update",
+				apperror.FieldViolation{Field: "update_mask", Description: "must name at least one field, or set one"})
+		}
+		return paths, nil
+	}
+
+	var violations []apperror.FieldViolation
+	for _, path := range req.UpdateMask.Paths {
+		if path != "relationship" && values[path] == "" {
+			violations = append(violations, apperror.FieldViolation{Field: path, Description: "is required when listed in update_mask"})
+		}
+	}
+	if len(violations) > 0 {
+		return nil, apperror.InvalidArgument("Request has invalid fields", violations...)
+	}
+	return req.UpdateMask.Paths, nil
+}
+
+func contactToPB(contact *model.EmergencyContact) *pb.EmergencyContact {
+	res := &pb.EmergencyContact{
+		Id:           contact.Id,
+		Name:         contact.Name,
+		PhoneNumber:  contact.PhoneNumber,
+		Relationship: contact.Relationship,
+		Verified:     contact.VerifiedAt != nil,
+		CreatedAt:    timestamppb.New(contact.CreatedAt),
+	}
+	if contact.VerifiedAt != nil {
+		res.VerifiedAt = timestamppb.New(*contact.VerifiedAt)
+	}
+	return res
+}
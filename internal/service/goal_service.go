@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/streak"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const monthLayout = "2006-01"
+
+func (s *UserServiceServer) SetGoal(ctx context.Context, req *pb.SetGoalRequest) (*pb.SetGoalResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	db := config.DB
+	userRepo := repository.NewUserRepo(db)
+	goalRepo := repository.NewGoalRepo(db)
+
+	user := model.User{Id: req.Id}
+	if err := userRepo.GetUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(user.Location())
+	month, err := parseMonth(req.Month, now)
+	if err != nil {
+		return nil, err
+	}
+	if month.Before(startOfMonth(now)) {
+		return nil, apperror.InvalidArgument("Invalid month",
+			apperror.FieldViolation{Field: "month", Description: "must be the current month or a later one"})
+	}
+
+	goal := &model.Goal{
+		UserId: req.Id,
+		Month:  month.Format(monthLayout),
+		Metric: goalMetricName(req.Metric),
+		Target: req.Target,
+	}
+	if err := goalRepo.Set(ctx, goal); err != nil {
+		log.Println("Failed to set goal:", err.Error())
+		return nil, err
+	}
+
+	return &pb.SetGoalResponse{Goal: goalToPB(goal)}, nil
+}
+
+func (s *UserServiceServer) GetGoalProgress(ctx context.Context, req *pb.GetGoalProgressRequest) (*pb.GetGoalProgressResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	db := config.DB
+	userRepo := repository.NewUserRepo(db)
+	goalRepo := repository.NewGoalRepo(db)
+	distanceRepo := repository.NewDistanceRepo(db)
+
+	user := model.User{Id: req.Id}
+	if err := userRepo.GetUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(user.Location())
+	start, err := parseMonth(req.Month, now)
+	if err != nil {
+		return nil, err
+	}
+	end := start.AddDate(0, 1, 0)
+
+	sums, err := distanceRepo.SumByVehicleType(ctx, req.Id, &start, &end)
+	if err != nil {
+		log.Println("Failed to sum distance:", err.Error())
+		return nil, err
+	}
+
+	res := &pb.GetGoalProgressResponse{TimeZone: user.Location().String()}
+	emissions := config.AppConfig.Emissions
+	for _, sum := range sums {
+		res.Distance += sum.Distance
+		res.Co2Saved += sum.Distance * emissions.SavedPerKm(sum.Kind, sum.VehicleType)
+	}
+
+	// Days left count from today in the current month, and all days in a later one
+	switch {
+	case !now.Before(end):
+		res.DaysLeft = 0
+	case now.Before(start):
+		res.DaysLeft = int32(end.Sub(start).Hours()/24 + 0.5)
+	default:
+		year, month, day := now.Date()
+		today := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+		res.DaysLeft = int32(end.Sub(today).Hours()/24 + 0.5)
+	}
+
+	goal, err := goalRepo.Get(ctx, req.Id, start.Format(monthLayout))
+	if err != nil {
+		return nil, err
+	}
+	if goal != nil {
+		progress := res.Distance
+		if goal.Metric == model.GoalMetricCO2Saved {
+			progress = res.Co2Saved
+		}
+
+		res.Goal = goalToPB(goal)
+		res.Percent = min(100, progress/goal.Target*100)
+		res.Achieved = progress >= goal.Target
+		if !res.Achieved && res.DaysLeft > 0 {
+			res.DailyNeeded = (goal.Target - progress) / float64(res.DaysLeft)
+		}
+	}
+
+	current, err := streak.Load(ctx, &user, now, config.AppConfig.Goals.StreakLookback)
+	if err != nil {
+		log.Println("Failed to load streak:", err.Error())
+		return nil, err
+	}
+	res.Streak = &pb.Streak{
+		Daily:  int32(current.Daily),
+		Weekly: int32(current.Weekly),
+		AtRisk: current.AtRisk(),
+	}
+	if current.LastTripAt != nil {
+		res.Streak.LastTripAt = timestamppb.New(*current.LastTripAt)
+	}
+
+	return res, nil
+}
+
+// Returns the start of a YYYY-MM month in the location of now, the current
+// month when empty
+func parseMonth(raw string, now time.Time) (time.Time, error) {
+	if raw == "" {
+		return startOfMonth(now), nil
+	}
+
+	month, err := time.ParseInLocation(monthLayout, raw, now.Location())
+	if err != nil {
+		return time.Time{}, apperror.InvalidArgument("Invalid month",
+			apperror.FieldViolation{Field: "month", Description: "must be a month in the YYYY-MM format"})
+	}
+	return month, nil
+}
+
+func startOfMonth(t time.Time) time.Time {
+	year, month, _ := t.Date()
+	return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+}
+
+func goalMetricName(metric pb.GoalMetric) string {
+	if metric == pb.GoalMetric_GOAL_METRIC_CO2_SAVED {
+		return model.GoalMetricCO2Saved
+	}
+	return model.GoalMetricDistance
+}
+
+func goalToPB(goal *model.Goal) *pb.Goal {
+	res := &pb.Goal{
+		Month:  goal.Month,
+		Metric: pb.GoalMetric_GOAL_METRIC_DISTANCE,
+		Target: goal.Target,
+	}
+	if goal.Metric == model.GoalMetricCO2Saved {
+		res.Metric = pb.GoalMetric_GOAL_METRIC_CO2_SAVED
+	}
+	return res
+}
@@ -1,34 +1,47 @@
 package service
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
-	"os"
 	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
 )
 
-func GenerateToken(userId uint64, expiry time.Duration) (string, error) {
+// Values of the "typ" claim, so refresh tokens are never accepted as access tokens
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+func GenerateToken(userId uint64, tokenType string, expiry time.Duration) (string, error) {
     expirationTime := time.Now().Add(expiry)
+
+	// Random token ID so two tokens issued in the same second never collide
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+
 	claims := jwt.MapClaims{
         "user_id": userId,
         "exp":     expirationTime.Unix(),
+        "jti":     hex.EncodeToString(jti),
+        "typ":     tokenType,
     }
 
     token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	
-	return token.SignedString([]byte(os.Getenv("JWT_SECRET")))
-}
-
-func getKey(userID uint64) string {
-    return "user_refresh_token:" + fmt.Sprint(userID)
+	return token.SignedString([]byte(config.AppConfig.JWT.Secret))
 }
 
-func ParseToken(tokenString, secret string) (int64, error) {
-	log.Println("Token:", tokenString)
+// Returns the user ID of a token of the given type signed with secret
+func ParseToken(tokenString, secret, tokenType string) (int64, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
@@ -47,8 +60,9 @@ func ParseToken(tokenString, secret string) (int64, error) {
 			return 0, errors.New("token expired")
 		}
 
-		// Debugging: log the type of claims["id"]
-		log.Printf("Type of 'user_id' in claims: %T\n", claims["user_id"])
+		if typ, _ := claims["typ"].(string); typ != tokenType {
+			return 0, fmt.Errorf("%s token expected", tokenType)
+		}
 
 		switch id := claims["user_id"].(type) {
 		case float64:
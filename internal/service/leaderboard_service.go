@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/cache"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/leaderboard"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *UserServiceServer) GetLeaderboard(ctx context.Context, req *pb.GetLeaderboardRequest) (*pb.GetLeaderboardResponse, error) {
+	// Page tokens are the opaque form of the rank the next page starts after
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	board := boardName(req.Board)
+	limit := pageSize(req.PageSize)
+
+	leaderboardCache := cache.NewLeaderboardCache(config.Redis)
+	top, err := leaderboardCache.Top(ctx, board, leaderboard.CityKey(req.City), now, int64(offset), int64(limit)+1)
+	if err != nil {
+		log.Println("Failed to get leaderboard:", err.Error())
+		return nil, err
+	}
+
+	// The extra user fetched only tells whether there is a next page
+	res := &pb.GetLeaderboardResponse{}
+	if len(top) > limit {
+		top = top[:limit]
+		res.NextPageToken = encodePageToken(offset + uint64(limit))
+	}
+	if start := leaderboard.PeriodStart(board, now); start != nil {
+		res.PeriodStart = timestamppb.New(*start)
+	}
+
+	ids := make([]uint64, 0, len(top))
+	for _, z := range top {
+		userId, err := strconv.ParseUint(z.Member.(string), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, userId)
+	}
+
+	names, err := repository.NewUserRepo(config.DB).ListNames(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// Other users are only shown by display name, never by ID or full name
+	for i, z := range top {
+		res.Entries = append(res.Entries, &pb.LeaderboardEntry{
+			Rank:        offset + uint64(i) + 1,
+			DisplayName: leaderboard.DisplayName(names[ids[i]]),
+			Co2Saved:    z.Score,
+		})
+	}
+
+	return res, nil
+}
+
+func (s *UserServiceServer) GetMyRank(ctx context.Context, req *pb.GetMyRankRequest) (*pb.GetMyRankResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	user := model.User{Id: req.Id}
+	if err := repository.NewUserRepo(config.DB).GetUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	// Users without a city are not on any city board
+	city := ""
+	if req.InCity {
+		city = leaderboard.CityKey(user.City)
+		if city == "" {
+			return nil, apperror.FailedPrecondition(apperror.ReasonNoCity, "Set a city on your profile to be ranked in it")
+		}
+	}
+
+	leaderboardCache := cache.NewLeaderboardCache(config.Redis)
+	rank, saved, total, err := leaderboardCache.Rank(ctx, boardName(req.Board), city, time.Now(), req.Id)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		log.Println("Failed to get leaderboard rank:", err.Error())
+		return nil, err
+	}
+
+	res := &pb.GetMyRankResponse{
+		Participants: uint64(total),
+		OptedOut:     user.LeaderboardOptOut,
+	}
+	if err == nil {
+		res.Ranked = true
+		res.Rank = uint64(rank) + 1
+		res.Co2Saved = saved
+	}
+
+	return res, nil
+}
+
+func (s *UserServiceServer) SetLeaderboardOptOut(ctx context.Context, req *pb.SetLeaderboardOptOutRequest) (*pb.SetLeaderboardOptOutResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	userRepo := repository.NewUserRepo(config.DB)
+	version, err := userRepo.SetLeaderboardOptOut(ctx, req.Id, req.OptOut, req.ExpectedVersion)
+	if err != nil {
+		log.Println("Failed to set leaderboard opt-out:", err.Error())
+		return nil, err
+	}
+
+	user := model.User{Id: req.Id}
+	if err := userRepo.GetUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	// Boards of past periods expire on their own, only the current ones change
+	now := time.Now()
+	if req.OptOut {
+		err = leaderboard.Remove(ctx, req.Id, user.City, now)
+	} else {
+		err = leaderboard.Restore(ctx, req.Id, user.City, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SetLeaderboardOptOutResponse{OptOut: req.OptOut, Version: version}, nil
+}
+
+// Ranks the user on the boards of their new city, logging failures
+func moveOnLeaderboards(ctx context.Context, userId uint64, oldCity, newCity string) {
+	if err := leaderboard.Move(ctx, userId, oldCity, newCity, time.Now()); err != nil {
+		log.Println("Failed to move user on leaderboards:", err.Error())
+	}
+}
+
+// Adds a ledger entry to the leaderboards, logging failures
+func recordOnLeaderboards(ctx context.Context, entry *model.DistanceEntry) {
+	if err := leaderboard.Record(ctx, entry); err != nil {
+		log.Println("Failed to update leaderboards:", err.Error())
+	}
+}
+
+func boardName(board pb.Leaderboard) string {
+	switch board {
+	case pb.Leaderboard_LEADERBOARD_WEEKLY:
+		return cache.BoardWeekly
+	case pb.Leaderboard_LEADERBOARD_MONTHLY:
+		return cache.BoardMonthly
+	default:
+		return cache.BoardAllTime
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/membership"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Number of tier changes returned by GetMembership
+const tierHistoryLength = 20
+
+func (s *UserServiceServer) GetMembership(ctx context.Context, req *pb.GetMembershipRequest) (*pb.GetMembershipResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	db := config.DB
+	userRepo := repository.NewUserRepo(db)
+	membershipRepo := repository.NewMembershipRepo(db)
+
+	if err := userRepo.GetUser(ctx, &model.User{Id: req.Id}); err != nil {
+		return nil, err
+	}
+
+	// Includes a downgrade whose grace period is over but not applied yet
+	now := time.Now()
+	current, activity, err := membership.Current(ctx, req.Id, now)
+	if err != nil {
+		log.Println("Failed to get membership:", err.Error())
+		return nil, err
+	}
+
+	tiers := config.AppConfig.Tier
+	windowStart := now.Add(-tiers.Window)
+
+	history, err := membershipRepo.History(ctx, req.Id, tierHistoryLength)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.GetMembershipResponse{
+		Tier:            tierToPB(current.Tier),
+		RollingDistance: activity.Distance,
+		RollingTrips:    activity.Trips,
+		WindowStart:     timestamppb.New(windowStart),
+	}
+	if next := nextTier(current.Tier); next != "" {
+		distance, trips := tiers.Thresholds(next)
+		res.NextTier = tierToPB(next)
+		res.NextTierDistanceNeeded = max(distance-activity.Distance, 0)
+		res.NextTierTripsNeeded = max(trips-activity.Trips, 0)
+	}
+	if current.DowngradeAt != nil {
+		res.DowngradeAt = timestamppb.New(*current.DowngradeAt)
+	}
+	for _, change := range history {
+		res.History = append(res.History, &pb.TierChange{
+			FromTier:        tierToPB(change.FromTier),
+			ToTier:          tierToPB(change.ToTier),
+			RollingDistance: change.RollingDistance,
+			RollingTrips:    change.RollingTrips,
+			ChangedAt:       timestamppb.New(change.CreatedAt),
+		})
+	}
+
+	return res, nil
+}
+
+// Re-evaluates the tier after the user's distance changed, logging failures
+func evaluateMembership(ctx context.Context, userId uint64) {
+	if _, err := membership.Evaluate(ctx, userId, time.Now()); err != nil {
+		log.Println("Failed to evaluate membership:", err.Error())
+	}
+}
+
+// Returns the tier above, or "" at the highest tier
+func nextTier(tier string) string {
+	switch tier {
+	case config.TierForest:
+		return ""
+	case config.TierSapling:
+		return config.TierForest
+	default:
+		return config.TierSapling
+	}
+}
+
+func tierToPB(tier string) pb.Tier {
+	switch tier {
+	case config.TierSeedling:
+		return pb.Tier_TIER_SEEDLING
+	case config.TierSapling:
+		return pb.Tier_TIER_SAPLING
+	case config.TierForest:
+		return pb.Tier_TIER_FOREST
+	default:
+		return pb.Tier_TIER_UNSPECIFIED
+	}
+}
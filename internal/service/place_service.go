@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"log"
+	"slices"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *UserServiceServer) CreateSavedPlace(ctx context.Context, req *pb.CreateSavedPlaceRequest) (*pb.CreateSavedPlaceResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+	if err := checkCoordinates(req.Latitude, req.Longitude); err != nil {
+		return nil, err
+	}
+
+	place := &model.SavedPlace{
+		UserId:    req.Id,
+		Label:     req.Label,
+		Address:   req.Address,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		PlaceType: placeTypeName(req.PlaceType),
+	}
+
+	placeRepo := repository.NewPlaceRepo(config.DB)
+	if err := placeRepo.Create(ctx, place, config.AppConfig.Places.MaxPerUser); err != nil {
+		log.Println("Failed to save place:", err.Error())
+		return nil, err
+	}
+
+	return &pb.CreateSavedPlaceResponse{Place: placeToPB(place)}, nil
+}
+
+func (s *UserServiceServer) ListSavedPlaces(ctx context.Context, req *pb.ListSavedPlacesRequest) (*pb.ListSavedPlacesResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	placeRepo := repository.NewPlaceRepo(config.DB)
+	places, err := placeRepo.List(ctx, req.Id)
+	if err != nil {
+		log.Println("Failed to list saved places:", err.Error())
+		return nil, err
+	}
+
+	res := &pb.ListSavedPlacesResponse{}
+	for i := range places {
+		res.Places = append(res.Places, placeToPB(&places[i]))
+	}
+	return res, nil
+}
+
+func (s *UserServiceServer) UpdateSavedPlace(ctx context.Context, req *pb.UpdateSavedPlaceRequest) (*pb.UpdateSavedPlaceResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	fields, err := updateSavedPlacePaths(req)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(fields, "latitude") {
+		if err := checkCoordinates(req.Latitude, req.Longitude); err != nil {
+			return nil, err
+		}
+	}
+
+	place := &model.SavedPlace{
+		Id:        req.PlaceId,
+		UserId:    req.Id,
+		Label:     req.Label,
+		Address:   req.Address,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		PlaceType: placeTypeName(req.PlaceType),
+	}
+
+	placeRepo := repository.NewPlaceRepo(config.DB)
+	if err := placeRepo.Update(ctx, place, fields); err != nil {
+		log.Println("Failed to update saved place:", err.Error())
+		return nil, err
+	}
+
+	return &pb.UpdateSavedPlaceResponse{Place: placeToPB(place)}, nil
+}
+
+func (s *UserServiceServer) DeleteSavedPlace(ctx context.Context, req *pb.DeleteSavedPlaceRequest) (*pb.DeleteSavedPlaceResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	placeRepo := repository.NewPlaceRepo(config.DB)
+	if err := placeRepo.Delete(ctx, req.Id, req.PlaceId); err != nil {
+		log.Println("Failed to delete saved place:", err.Error())
+		return nil, err
+	}
+
+	return &pb.DeleteSavedPlaceResponse{Message: "Saved place deleted successfully!"}, nil
+}
+
+// Returns the fields to update. Latitude and longitude are updated together,
+// and as 0 is a valid coordinate, without a mask whenever either is set.
+func updateSavedPlacePaths(req *pb.UpdateSavedPlaceRequest) ([]string, error) {
+	set := map[string]bool{
+		"label":      req.Label != "",
+		"address":    req.Address != "",
+		"latitude":   req.Latitude != 0 || req.Longitude != 0,
+		"longitude":  req.Latitude != 0 || req.Longitude != 0,
+		"place_type": req.PlaceType != pb.PlaceType_PLACE_TYPE_UNSPECIFIED,
+	}
+
+	if len(req.UpdateMask.GetPaths()) == 0 {
+		var paths []string
+		for _, path := range []string{"label", "address", "latitude", "longitude", "place_type"} {
+			if set[path] {
+				paths = append(paths, path)
+			}
+		}
+		if len(paths) == 0 {
+			return nil, apperror.InvalidArgument("Nothing to update",
+				apperror.FieldViolation{Field: "update_mask", Description: "must name at least one field, or set one"})
+		}
+		return paths, nil
+	}
+
+	var violations []apperror.FieldViolation
+	paths := req.UpdateMask.Paths
+	for _, path := range paths {
+		if path != "latitude" && path != "longitude" && !set[path] {
+			violations = append(violations, apperror.FieldViolation{Field: path, Description: "is required when listed in update_mask"})
+		}
+	}
+	if slices.Contains(paths, "latitude") != slices.Contains(paths, "longitude") {
+		violations = append(violations, apperror.FieldViolation{Field: "update_mask", Description: "must list latitude and longitude together"})
+	}
+	if len(violations) > 0 {
+		return nil, apperror.InvalidArgument("Request has invalid fields", violations...)
+	}
+	return paths, nil
+}
+
+// Rejects 0, 0, which is in the ocean and almost always a location that was never set
+func checkCoordinates(latitude, longitude float64) error {
+	if latitude == 0 && longitude == 0 {
+		return apperror.InvalidArgument("Invalid coordinates",
+			apperror.FieldViolation{Field: "latitude", Description: "latitude and longitude cannot both be 0"})
+	}
+	return nil
+}
+
+func placeTypeName(placeType pb.PlaceType) string {
+	switch placeType {
+	case pb.PlaceType_PLACE_TYPE_HOME:
+		return model.PlaceHome
+	case pb.PlaceType_PLACE_TYPE_WORK:
+		return model.PlaceWork
+	default:
+		return model.PlaceFavourite
+	}
+}
+
+func placeToPB(place *model.SavedPlace) *pb.SavedPlace {
+	res := &pb.SavedPlace{
+		Id:        place.Id,
+		Label:     place.Label,
+		Address:   place.Address,
+		Latitude:  place.Latitude,
+		Longitude: place.Longitude,
+		PlaceType: pb.PlaceType_PLACE_TYPE_FAVOURITE,
+		CreatedAt: timestamppb.New(place.CreatedAt),
+		UpdatedAt: timestamppb.New(place.UpdatedAt),
+	}
+	switch place.PlaceType {
+	case model.PlaceHome:
+		res.PlaceType = pb.PlaceType_PLACE_TYPE_HOME
+	case model.PlaceWork:
+		res.PlaceType = pb.PlaceType_PLACE_TYPE_WORK
+	}
+	return res
+}
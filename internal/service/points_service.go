@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *UserServiceServer) GetPointsBalance(ctx context.Context, req *pb.GetPointsBalanceRequest) (*pb.GetPointsBalanceResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	db := config.DB
+	pointsRepo := repository.NewPointsRepo(db)
+
+	balance, next, err := pointsRepo.GetBalance(ctx, req.Id)
+	if err != nil {
+		log.Println("Failed to get points balance:", err.Error())
+		return nil, err
+	}
+
+	res := &pb.GetPointsBalanceResponse{Balance: balance}
+	if next != nil {
+		res.NextExpiringPoints = next.Remaining
+		res.NextExpiryTime = timestamppb.New(*next.ExpiresAt)
+	}
+
+	return res, nil
+}
+
+func (s *UserServiceServer) ListPointsTransactions(ctx context.Context, req *pb.ListPointsTransactionsRequest) (*pb.ListPointsTransactionsResponse, error) {
+	if err := checkUserOrAdminAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	beforeId, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &model.PointsTransactionFilter{
+		BeforeId: beforeId,
+		Limit:    pageSize(req.PageSize) + 1,
+	}
+
+	db := config.DB
+	pointsRepo := repository.NewPointsRepo(db)
+
+	txns, err := pointsRepo.ListTransactions(ctx, req.Id, filter)
+	if err != nil {
+		log.Println("Failed to list points transactions:", err.Error())
+		return nil, err
+	}
+
+	// The extra transaction fetched only tells whether there is a next page
+	res := &pb.ListPointsTransactionsResponse{}
+	if len(txns) == filter.Limit {
+		txns = txns[:len(txns)-1]
+		res.NextPageToken = encodePageToken(txns[len(txns)-1].Id)
+	}
+	for i := range txns {
+		res.Transactions = append(res.Transactions, pointsTransactionToPB(&txns[i]))
+	}
+
+	return res, nil
+}
+
+func (s *UserServiceServer) RedeemPoints(ctx context.Context, req *pb.RedeemPointsRequest) (*pb.RedeemPointsResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	db := config.DB
+	pointsRepo := repository.NewPointsRepo(db)
+
+	txn, err := pointsRepo.Apply(ctx, &repository.PointsChange{
+		UserId:         req.Id,
+		Kind:           model.PointsRedeem,
+		Points:         -req.Points,
+		IdempotencyKey: req.IdempotencyKey,
+		Description:    req.Description,
+	})
+	if err != nil {
+		log.Println("Failed to redeem points:", err.Error())
+		return nil, err
+	}
+
+	return &pb.RedeemPointsResponse{Transaction: pointsTransactionToPB(txn), Balance: txn.BalanceAfter}, nil
+}
+
+func (s *UserServiceServer) AdjustPoints(ctx context.Context, req *pb.AdjustPointsRequest) (*pb.AdjustPointsResponse, error) {
+	adminId, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db := config.DB
+	userRepo := repository.NewUserRepo(db)
+	pointsRepo := repository.NewPointsRepo(db)
+
+	if err := userRepo.GetUser(ctx, &model.User{Id: req.Id}); err != nil {
+		return nil, err
+	}
+
+	txn, err := pointsRepo.Apply(ctx, &repository.PointsChange{
+		UserId:         req.Id,
+		Kind:           model.PointsAdjust,
+		Points:         req.Points,
+		IdempotencyKey: req.IdempotencyKey,
+		Description:    req.Reason,
+		CreatedBy:      adminId,
+		ExpiresAt:      time.Now().Add(config.AppConfig.Points.ExpiresAfter),
+	})
+	if err != nil {
+		log.Println("Failed to adjust points:", err.Error())
+		return nil, err
+	}
+
+	log.Printf("Admin %d adjusted points of user %d by %d: %s", adminId, req.Id, req.Points, req.Reason)
+
+	return &pb.AdjustPointsResponse{Transaction: pointsTransactionToPB(txn), Balance: txn.BalanceAfter}, nil
+}
+
+func pointsTransactionToPB(txn *model.PointsTransaction) *pb.PointsTransaction {
+	res := &pb.PointsTransaction{
+		Id:           txn.Id,
+		Kind:         pointsTransactionKindToPB(txn.Kind),
+		Points:       txn.Points,
+		BalanceAfter: txn.BalanceAfter,
+		Description:  txn.Description,
+		CreatedAt:    timestamppb.New(txn.CreatedAt),
+	}
+	if txn.ExpiresAt != nil {
+		res.ExpiresAt = timestamppb.New(*txn.ExpiresAt)
+	}
+	return res
+}
+
+func pointsTransactionKindToPB(kind string) pb.PointsTransactionKind {
+	switch kind {
+	case model.PointsEarn:
+		return pb.PointsTransactionKind_POINTS_TRANSACTION_KIND_EARN
+	case model.PointsRedeem:
+		return pb.PointsTransactionKind_POINTS_TRANSACTION_KIND_REDEEM
+	case model.PointsExpire:
+		return pb.PointsTransactionKind_POINTS_TRANSACTION_KIND_EXPIRE
+	case model.PointsAdjust:
+		return pb.PointsTransactionKind_POINTS_TRANSACTION_KIND_ADJUST
+	default:
+		return pb.PointsTransactionKind_POINTS_TRANSACTION_KIND_UNSPECIFIED
+	}
+}
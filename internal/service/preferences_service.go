@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+)
+
+func (s *UserServiceServer) GetPreferences(ctx context.Context, req *pb.GetPreferencesRequest) (*pb.GetPreferencesResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	user := model.User{Id: req.Id}
+	if err := repository.NewUserRepo(config.DB).GetUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	preferencesRepo := repository.NewPreferencesRepo(config.DB)
+	preferences, err := preferencesRepo.Get(ctx, req.Id)
+	if err != nil {
+		log.Println("Failed to get preferences:", err.Error())
+		return nil, err
+	}
+
+	return &pb.GetPreferencesResponse{Preferences: preferencesToPB(preferences), Version: preferences.Version}, nil
+}
+
+func (s *UserServiceServer) UpdatePreferences(ctx context.Context, req *pb.UpdatePreferencesRequest) (*pb.UpdatePreferencesResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	paths := req.UpdateMask.GetPaths()
+	if len(paths) == 0 {
+		return nil, apperror.InvalidArgument("Nothing to update",
+			apperror.FieldViolation{Field: "update_mask", Description: "must name at least one field"})
+	}
+
+	in := req.Preferences
+	if in == nil {
+		in = &pb.Preferences{}
+	}
+	var violations []apperror.FieldViolation
+	if slices.Contains(paths, "language") && !slices.Contains(config.Languages, in.Language) {
+		violations = append(violations, apperror.FieldViolation{Field: "preferences.language",
+			Description: "must be one of " + strings.Join(config.Languages, ", ")})
+	}
+	if slices.Contains(paths, "distance_unit") && in.DistanceUnit == pb.DistanceUnit_DISTANCE_UNIT_UNSPECIFIED {
+		violations = append(violations, apperror.FieldViolation{Field: "preferences.distance_unit", Description: "is required when listed in update_mask"})
+	}
+	if len(violations) > 0 {
+		return nil, apperror.InvalidArgument("Request has invalid fields", violations...)
+	}
+
+	preferencesRepo := repository.NewPreferencesRepo(config.DB)
+	preferences, err := preferencesRepo.Update(ctx, req.Id, req.ExpectedVersion, func(p *model.Preferences) {
+		for _, path := range paths {
+			switch path {
+			case "language":
+				p.Language = in.Language
+			case "distance_unit":
+				p.DistanceUnit = distanceUnitName(in.DistanceUnit)
+			case "quiet_ride":
+				p.QuietRide = in.QuietRide
+			case "ev_only":
+				p.EVOnly = in.EvOnly
+			case "wheelchair_accessible":
+				p.WheelchairAccessible = in.WheelchairAccessible
+			case "service_animal":
+				p.ServiceAnimal = in.ServiceAnimal
+			case "hearing_impaired":
+				p.HearingImpaired = in.HearingImpaired
+			case "streak_reminders":
+				p.StreakReminders = in.StreakReminders
+			case "marketing_emails":
+				p.MarketingEmails = in.MarketingEmails
+			case "marketing_sms":
+				p.MarketingSMS = in.MarketingSms
+			}
+		}
+	})
+	if err != nil {
+		log.Println("Failed to update preferences:", err.Error())
+		return nil, err
+	}
+
+	return &pb.UpdatePreferencesResponse{Preferences: preferencesToPB(preferences), Version: preferences.Version}, nil
+}
+
+// Returns the language to write to a user in, the default one when their
+// preferences cannot be read
+func userLanguage(ctx context.Context, userId uint64) string {
+	preferences, err := repository.NewPreferencesRepo(config.DB).Get(ctx, userId)
+	if err != nil {
+		log.Println("Failed to get preferences:", err.Error())
+		return config.AppConfig.Preferences.DefaultLanguage
+	}
+	return preferences.Language
+}
+
+// Like userLanguage, for the user with an email
+func emailLanguage(ctx context.Context, email string) string {
+	user := &model.User{}
+	if err := config.DB.WithContext(ctx).Select("id").Where("email = ?", email).First(user).Error; err != nil {
+		return config.AppConfig.Preferences.DefaultLanguage
+	}
+	return userLanguage(ctx, user.Id)
+}
+
+func distanceUnitName(unit pb.DistanceUnit) string {
+	if unit == pb.DistanceUnit_DISTANCE_UNIT_MILES {
+		return config.DistanceUnitMiles
+	}
+	return config.DistanceUnitKm
+}
+
+func preferencesToPB(p *model.Preferences) *pb.Preferences {
+	res := &pb.Preferences{
+		Language:             p.Language,
+		DistanceUnit:         pb.DistanceUnit_DISTANCE_UNIT_KM,
+		QuietRide:            p.QuietRide,
+		EvOnly:               p.EVOnly,
+		WheelchairAccessible: p.WheelchairAccessible,
+		ServiceAnimal:        p.ServiceAnimal,
+		HearingImpaired:      p.HearingImpaired,
+		StreakReminders:      p.StreakReminders,
+		MarketingEmails:      p.MarketingEmails,
+		MarketingSms:         p.MarketingSMS,
+	}
+	if p.DistanceUnit == config.DistanceUnitMiles {
+		res.DistanceUnit = pb.DistanceUnit_DISTANCE_UNIT_MILES
+	}
+	return res
+}
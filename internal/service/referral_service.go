@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/referral"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+)
+
+func (s *UserServiceServer) GetReferralStats(ctx context.Context, req *pb.GetReferralStatsRequest) (*pb.GetReferralStatsResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	// Users who signed up before referrals existed get their code here
+	code, err := referral.Code(ctx, req.Id)
+	if err != nil {
+		log.Println("Failed to get referral code:", err.Error())
+		return nil, err
+	}
+
+	referralRepo := repository.NewReferralRepo(config.DB)
+	stats, err := referralRepo.Stats(ctx, req.Id)
+	if err != nil {
+		log.Println("Failed to get referral stats:", err.Error())
+		return nil, err
+	}
+
+	return &pb.GetReferralStatsResponse{
+		ReferralCode: code,
+		ReferralLink: config.AppConfig.FrontendURL + "/signup?ref=" + url.QueryEscape(code),
+		Pending:      stats.Pending,
+		Rewarded:     stats.Rewarded,
+		Rejected:     stats.Rejected,
+		PointsEarned: stats.PointsEarned,
+	}, nil
+}
+
+// Rewards the user's pending referral after a trip, logging failures
+func rewardReferral(ctx context.Context, userId uint64) {
+	if err := referral.Reward(ctx, userId, time.Now()); err != nil {
+		log.Println("Failed to reward referral:", err.Error())
+	}
+}
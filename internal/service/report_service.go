@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/report"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+)
+
+// Longest period one report covers, a leap year
+const maxReportPeriod = 366 * 24 * time.Hour
+
+func (s *UserServiceServer) GenerateEcoReport(ctx context.Context, req *pb.GenerateEcoReportRequest) (*pb.GenerateEcoReportResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	if req.StartTime == nil || req.EndTime == nil {
+		return nil, apperror.InvalidArgument("Invalid time range",
+			apperror.FieldViolation{Field: "start_time", Description: "start_time and end_time are required"})
+	}
+	start, end := req.StartTime.AsTime(), req.EndTime.AsTime()
+	if !end.After(start) {
+		return nil, apperror.InvalidArgument("Invalid time range",
+			apperror.FieldViolation{Field: "end_time", Description: "must be after start_time"})
+	}
+	if end.Sub(start) > maxReportPeriod {
+		return nil, apperror.InvalidArgument("Invalid time range",
+			apperror.FieldViolation{Field: "end_time", Description: "must be at most 366 days after start_time"})
+	}
+
+	userRepo := repository.NewUserRepo(config.DB)
+	user := model.User{Id: req.Id}
+	if err := userRepo.GetUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	r, err := report.Build(ctx, &user, start, end, time.Now())
+	if errors.Is(err, report.ErrTooManyEntries) {
+		return nil, apperror.InvalidArgument("Too many entries for one report",
+			apperror.FieldViolation{Field: "end_time", Description: "the period has more than " + strconv.Itoa(report.MaxEntries) + " entries, choose a shorter one"})
+	}
+	if err != nil {
+		log.Println("Failed to build eco report:", err.Error())
+		return nil, err
+	}
+
+	res := &pb.GenerateEcoReportResponse{}
+	switch req.Format {
+	case pb.ReportFormat_REPORT_FORMAT_PDF:
+		res.Filename = r.Filename() + ".pdf"
+		res.ContentType = "application/pdf"
+		res.Content = r.PDF()
+	default:
+		content, err := r.CSV()
+		if err != nil {
+			log.Println("Failed to render eco report:", err.Error())
+			return nil, err
+		}
+		res.Filename = r.Filename() + ".csv"
+		res.ContentType = "text/csv; charset=utf-8"
+		res.Content = content
+	}
+
+	return res, nil
+}
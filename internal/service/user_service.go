@@ -3,20 +3,27 @@ package service
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"os"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
 	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/cache"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/email"
 	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/leaderboard"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/membership"
 	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/referral"
 	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
 	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/utils"
 
+	"github.com/redis/go-redis/v9"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 type UserServiceServer struct {
@@ -24,8 +31,9 @@ type UserServiceServer struct {
 }
 
 func (s *UserServiceServer) SignUp(ctx context.Context, req *pb.SignUpRequest) (*pb.SignUpResponse, error) {
-	if req.Name == "" || req.PhoneNumber == "" || req.Email == "" || req.Password == "" {
-		return nil, errors.New("Name, Phone Number, Email and Password are required")
+	phoneNumber, err := normalizePhoneNumber("phone_number", req.PhoneNumber)
+	if err != nil {
+		return nil, err
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
@@ -36,23 +44,61 @@ func (s *UserServiceServer) SignUp(ctx context.Context, req *pb.SignUpRequest) (
 
 	signUpData := &model.SignUpUserData{
 		Name:      req.Name,
-		PhoneNumber: req.PhoneNumber,
+		PhoneNumber: phoneNumber,
 		Email: req.Email,
 		Password:  string(hashedPassword),
+		SignupDeviceId: req.DeviceId,
 	}
 
 	db := config.DB
 	userRepo := repository.NewUserRepo(db)
 
-	if err := userRepo.SignUp(ctx, signUpData); err != nil {
+	// An unknown code is most likely mistyped, the user can fix it
+	var referrer *model.User
+	if req.ReferralCode != "" {
+		// Referral fraud is spotted by device, a referral needs one
+		if req.DeviceId == "" {
+			return nil, apperror.InvalidArgument("Device ID is required with a referral code",
+				apperror.FieldViolation{Field: "device_id", Description: "is required with a referral code"})
+		}
+
+		referrer, err = userRepo.GetByReferralCode(ctx, strings.ToUpper(strings.TrimSpace(req.ReferralCode)))
+		if apperror.HasReason(err, apperror.ReasonUserNotFound) {
+			return nil, apperror.InvalidArgument("Invalid referral code",
+				apperror.FieldViolation{Field: "referral_code", Description: "does not belong to any user"}).
+				WithReason(apperror.ReasonInvalidReferralCode)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The referral is recorded with the user, a sign up never loses it
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := repository.NewUserRepo(tx).SignUp(ctx, signUpData); err != nil {
+			return err
+		}
+		if referrer == nil {
+			return nil
+		}
+		_, err := referral.Register(ctx, tx, referrer, signUpData)
+		return err
+	})
+	if err != nil {
 		log.Println("Failed to signup:", err.Error())
 		return nil, err
 	}
 
+	// GetReferralStats assigns the code later when this fails
+	if _, err := referral.Code(ctx, signUpData.Id); err != nil {
+		log.Println("Failed to assign referral code:", err.Error())
+	}
+
 	// Send verification email
-	verificationLink := "http://localhost:5173"
-	emailBody := fmt.Sprintf("Hello %s, <br> Please verify your email by clicking <a href='%s'>here</a> and log in.", req.Name, verificationLink)
-	if err := utils.SendEmail(req.Email, "Verify Your Email", emailBody); err != nil {
+	verificationLink := config.AppConfig.FrontendURL
+	// New users have the default preferences, so the default language
+	data := map[string]interface{}{"Name": req.Name, "Link": verificationLink}
+	if err := email.Send(req.Email, config.AppConfig.Preferences.DefaultLanguage, email.VerifyEmail, data); err != nil {
 		log.Println("Failed to send verification email:", err.Error())
 		return nil, err
 	}
@@ -61,12 +107,13 @@ func (s *UserServiceServer) SignUp(ctx context.Context, req *pb.SignUpRequest) (
 }
 	
 func (s *UserServiceServer) LogIn(ctx context.Context, req *pb.LogInRequest) (*pb.LogInResponse, error) {
-	if req.PhoneNumber == "" || req.Password == "" {
-		return nil, errors.New("Phone Number and Password are required")
+	phoneNumber, err := normalizePhoneNumber("phone_number", req.PhoneNumber)
+	if err != nil {
+		return nil, err
 	}
 
 	logInData := &model.LogInUserData{
-		PhoneNumber: req.PhoneNumber,
+		PhoneNumber: phoneNumber,
 		Password:  string(req.Password),
 	}
 
@@ -81,13 +128,17 @@ func (s *UserServiceServer) LogIn(ctx context.Context, req *pb.LogInRequest) (*p
 	}
 
 
-	accessToken, err := GenerateToken(user.Id, 15*time.Minute)
+	clientType := clientTypeName(req.ClientType)
+	policy := config.AppConfig.Session.Policy(clientType, req.RememberMe)
+
+	accessToken, err := GenerateToken(user.Id, TokenTypeAccess, policy.AccessTokenTTL)
 	if err != nil {
 		log.Println("Failed to generate access token:", err.Error())
 		return nil, err
 	}
 
-	refreshToken, err := GenerateToken(user.Id, 24*time.Hour)
+	// The refresh token lives until the session's max age, Redis enforces the idle timeout
+	refreshToken, err := GenerateToken(user.Id, TokenTypeRefresh, policy.MaxAge)
 	if err != nil {
 		log.Println("Failed to generate refresh token:", err.Error())
 		return nil, err
@@ -96,11 +147,14 @@ func (s *UserServiceServer) LogIn(ctx context.Context, req *pb.LogInRequest) (*p
 	rdb := config.Redis
 	sessionCache := cache.NewSessionCache(rdb)
 
-	if sessionCache == nil {
-        return nil, fmt.Errorf("Session Cache is not initialized")
-    }
-
-	err = sessionCache.StoreRefreshToken(ctx, user.Id, refreshToken)
+	err = sessionCache.StoreRefreshToken(ctx, refreshToken, &cache.Session{
+		UserId:      user.Id,
+		ClientType:  clientType,
+		RememberMe:  req.RememberMe,
+		CreatedAt:   time.Now(),
+		IdleTimeout: policy.IdleTimeout,
+		MaxAge:      policy.MaxAge,
+	})
 
 	if err != nil {
 		log.Println("Failed to store refresh token:", err.Error())
@@ -111,6 +165,10 @@ func (s *UserServiceServer) LogIn(ctx context.Context, req *pb.LogInRequest) (*p
 }
 
 func (s *UserServiceServer) LogOut(ctx context.Context, req *pb.LogOutRequest) (*pb.LogOutResponse, error) {
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
 	rdb := config.Redis
 	sessionCache := cache.NewSessionCache(rdb)
 
@@ -122,9 +180,82 @@ func (s *UserServiceServer) LogOut(ctx context.Context, req *pb.LogOutRequest) (
 	return &pb.LogOutResponse{Message: "User logged out successfully"}, nil
 }
 
+// Kind of the pending change a password reset code confirms
+const passwordResetKind = "password"
+
+// Sends a code to reset the password with to the email. The response is the
+// same whether or not an account uses the email, so it cannot be used to
+// find out which ones do.
 func (s *UserServiceServer) ForgotPassword(ctx context.Context, req *pb.ForgotPasswordRequest) (*pb.ForgotPasswordResponse, error) {
-	if req.Email == "" || req.NewPassword == "" {
-		return nil, errors.New("Missing required fields")
+	res := &pb.ForgotPasswordResponse{Message: "If an account uses this email, a reset code was sent to it"}
+
+	userRepo := repository.NewUserRepo(config.DB)
+	user, err := userRepo.GetUserByEmail(ctx, req.Email)
+	if apperror.HasReason(err, apperror.ReasonUserNotFound) {
+		return res, nil
+	}
+	if err != nil {
+		log.Println("Failed to get user:", err.Error())
+		return nil, err
+	}
+
+	code, err := utils.GenerateCode(config.VerificationCodeLength)
+	if err != nil {
+		return nil, err
+	}
+
+	verification := config.AppConfig.Verification
+	verificationCache := cache.NewVerificationCache(config.Redis)
+
+	// The code is only good for the email it was sent to
+	if err := verificationCache.StorePendingChange(ctx, user.Id, passwordResetKind, user.Email, hashCode(code), 0, verification.CodeTTL); err != nil {
+		log.Println("Failed to store password reset:", err.Error())
+		return nil, err
+	}
+
+	data := map[string]interface{}{"Name": user.Name, "Code": code, "Minutes": int(verification.CodeTTL.Minutes())}
+	if err := email.Send(user.Email, userLanguage(ctx, user.Id), email.ResetPassword, data); err != nil {
+		// Reporting it would tell the caller the account exists
+		log.Println("Failed to send password reset code:", err.Error())
+	}
+
+	return res, nil
+}
+
+// Sets a new password once the code ForgotPassword sent matches, and signs
+// the user out everywhere
+func (s *UserServiceServer) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	noReset := apperror.FailedPrecondition(apperror.ReasonNoPendingChange, "There is no password reset to confirm, or it has expired, please request a new code")
+
+	userRepo := repository.NewUserRepo(config.DB)
+	user, err := userRepo.GetUserByEmail(ctx, req.Email)
+	if apperror.HasReason(err, apperror.ReasonUserNotFound) {
+		return nil, noReset
+	}
+	if err != nil {
+		log.Println("Failed to get user:", err.Error())
+		return nil, err
+	}
+
+	verificationCache := cache.NewVerificationCache(config.Redis)
+
+	pending, err := verificationCache.CheckPendingChange(ctx, user.Id, passwordResetKind, hashCode(req.Code), config.AppConfig.Verification.MaxAttempts)
+	switch {
+	case errors.Is(err, redis.Nil):
+		return nil, noReset
+	case errors.Is(err, cache.ErrInvalidCode):
+		return nil, apperror.InvalidArgument("Invalid verification code",
+			apperror.FieldViolation{Field: "code", Description: "does not match the code that was sent"}).
+			WithReason(apperror.ReasonInvalidCode)
+	case errors.Is(err, cache.ErrTooManyAttempts):
+		return nil, apperror.ResourceExhausted(apperror.ReasonTooManyAttempts, "Too many invalid codes, please request a new code")
+	case err != nil:
+		return nil, err
+	}
+
+	// A code sent before the account's email changed does not prove the new one
+	if pending.Value != user.Email {
+		return nil, noReset
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
@@ -133,60 +264,128 @@ func (s *UserServiceServer) ForgotPassword(ctx context.Context, req *pb.ForgotPa
 		return nil, err
 	}
 
-	forgotPasswordUserData := &model.ChangePasswordUserData{
-		NewPassword: string(hashedPassword),
+	// Signed out first, so sessions of whoever knew the old password never
+	// outlive it
+	sessionCache := cache.NewSessionCache(config.Redis)
+	if err := sessionCache.DeleteRefreshToken(ctx, user.Id); err != nil {
+		log.Println("Failed to sign out user before password reset:", err.Error())
+		return nil, err
 	}
 
-	db := config.DB
-	userRepo := repository.NewUserRepo(db)
-
-	if err := userRepo.ForgotPassword(ctx, forgotPasswordUserData, req.Email); err != nil {
+	if err := userRepo.ResetPassword(ctx, &model.ChangePasswordUserData{NewPassword: string(hashedPassword)}, user.Id); err != nil {
 		log.Println("Failed to reset password:", err.Error())
 		return nil, err
 	}
 
-	// Send verification email
-	verificationLink := "http://localhost:5173"
-	emailBody := fmt.Sprintf("<br> Please verify your email by clicking <a href='%s'>here</a> to change your password and log in.", verificationLink)
-	if err := utils.SendEmail(req.Email, "Verify Your Email", emailBody); err != nil {
-		log.Println("Failed to send verification email:", err.Error())
-		return nil, err
+	// The code must not work twice
+	if err := verificationCache.DeletePendingChange(ctx, user.Id, passwordResetKind); err != nil {
+		log.Println("Failed to delete password reset:", err.Error())
 	}
 
-	return &pb.ForgotPasswordResponse{Message: "Password reset successfully!"}, nil
+	return &pb.ResetPasswordResponse{Message: "Password reset successfully, please log in again"}, nil
 }
 
 func (s *UserServiceServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
-	if req.Id == 0 || req.Name == "" || req.PhoneNumber == "" || req.Email == "" {
-		return nil, errors.New("Missing required fields")
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
 	}
 
-	updateData := &model.UpdateUserData{
-		Name:        req.Name,
-		PhoneNumber: req.PhoneNumber,
-		Email: req.Email,
+	paths, err := updateUserPaths(req)
+	if err != nil {
+		return nil, err
 	}
 
 	db := config.DB
 	userRepo := repository.NewUserRepo(db)
 
-	if err := userRepo.UpdateUser(ctx, updateData, uint64(req.Id)); err != nil {
-		log.Println("Failed to update user:", err.Error())
+	user := model.User{Id: req.Id}
+	if err := userRepo.GetUser(ctx, &user); err != nil {
+		log.Println("Failed to get user:", err.Error())
 		return nil, err
 	}
 
-	return &pb.UpdateUserResponse{Message: "User updated successfully!"}, nil
+	// Checked before any code is sent, the update itself is guarded again
+	if req.ExpectedVersion != 0 && req.ExpectedVersion != user.Version {
+		return nil, repository.VersionMismatchError(user.Version)
+	}
+
+	updateData := &model.UpdateUserData{Name: req.Name, TimeZone: req.TimeZone, City: strings.TrimSpace(req.City)}
+	var fields, pending []string
+	// New email and phone number by kind, only applied once their code is confirmed
+	contacts := map[string]string{}
+
+	for _, path := range paths {
+		switch path {
+		case "name", "time_zone", "city":
+			fields = append(fields, path)
+		case "email":
+			if req.Email != user.Email {
+				contacts[contactEmail] = req.Email
+			}
+		case "phone_number":
+			phoneNumber, err := normalizePhoneNumber("phone_number", req.PhoneNumber)
+			if err != nil {
+				return nil, err
+			}
+			if phoneNumber != user.PhoneNumber {
+				contacts[contactPhoneNumber] = phoneNumber
+			}
+		}
+	}
+
+	// Checked again when the change is requested, but before anything is written
+	for kind, value := range contacts {
+		if err := userRepo.CheckContactAvailable(ctx, kind, value, user.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	version := user.Version
+	if len(fields) > 0 {
+		version, err = userRepo.UpdateUser(ctx, updateData, uint64(req.Id), fields, req.ExpectedVersion)
+		if err != nil {
+			log.Println("Failed to update user:", err.Error())
+			return nil, err
+		}
+	}
+
+	// Confirming the codes must not undo an update made after this one, so the
+	// pending changes expect the version this update left the user at
+	var pendingVersion uint64
+	if req.ExpectedVersion != 0 {
+		pendingVersion = version
+	}
+	for _, kind := range []string{contactEmail, contactPhoneNumber} {
+		value, ok := contacts[kind]
+		if !ok {
+			continue
+		}
+		if err := requestContactChange(ctx, &user, kind, value, pendingVersion); err != nil {
+			return nil, err
+		}
+		pending = append(pending, kind)
+	}
+
+	if slices.Contains(fields, "city") && leaderboard.CityKey(updateData.City) != leaderboard.CityKey(user.City) {
+		moveOnLeaderboards(ctx, user.Id, user.City, updateData.City)
+	}
+
+	message := "User updated successfully!"
+	if len(pending) > 0 {
+		message = "User updated, confirm the code sent to the new contact details to apply them"
+	}
+
+	return &pb.UpdateUserResponse{Message: message, PendingVerification: pending, Version: version}, nil
 }
 
 func (s *UserServiceServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
 	var user model.User;
-	// Checking if the request contains a valid user ID
-	if req.Id == 0 {
-		return nil, errors.New("Id is required")
-	}
-
 	user.Id = req.Id
 
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
 	// Establishing a database connection and initializing the repository
 	db := config.DB
 	userRepo := repository.NewUserRepo(db)
@@ -199,6 +398,13 @@ func (s *UserServiceServer) GetUser(ctx context.Context, req *pb.GetUserRequest)
 		return nil, err
 	}
 
+	// Same tier as GetMembership, a downgrade past its grace period included
+	current, _, err := membership.Current(ctx, user.Id, time.Now())
+	if err != nil {
+		log.Println("Failed to get membership:", err.Error())
+		return nil, err
+	}
+
 	// Mapping model.User to pb.GetUserResponse
 	log.Println("User's Distance Travelled:", user.DistanceTravelled)
 	userResponse := &pb.GetUserResponse{
@@ -207,6 +413,11 @@ func (s *UserServiceServer) GetUser(ctx context.Context, req *pb.GetUserRequest)
 		PhoneNumber: user.PhoneNumber,
 		Email: user.Email,
 		DistanceTravelled: user.DistanceTravelled,
+		Version: user.Version,
+		Tier: tierToPB(current.Tier),
+		LeaderboardOptOut: user.LeaderboardOptOut,
+		TimeZone: user.TimeZone,
+		City: user.City,
 	}
 
 	return userResponse, nil
@@ -214,8 +425,8 @@ func (s *UserServiceServer) GetUser(ctx context.Context, req *pb.GetUserRequest)
 }
 
 func (s *UserServiceServer) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
-	if req.Id ==0 || req.OldPassword == "" || req.NewPassword == "" { 
-		return nil, errors.New("Missing required fields")
+	if err := checkUserAccess(ctx, req.Id); err != nil {
+		return nil, err
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
@@ -240,40 +451,56 @@ func (s *UserServiceServer) ChangePassword(ctx context.Context, req *pb.ChangePa
 }
 
 func (s *UserServiceServer) UpdateDistanceTravelled(ctx context.Context, req *pb.UpdateDistanceTravelledRequest) (*pb.UpdateDistanceTravelledResponse, error) {
-	// Validating the request inputs
-	if req.Id == 0 {
-		return nil, errors.New("Id is required")
+	// Trips are only recorded by the trip service, riders could mint points otherwise
+	if err := requireService(ctx); err != nil {
+		return nil, err
 	}
 
-	if req.Distance <= 0 {
-		return nil, errors.New("Distance must be a positive number")
-	}
+	db := config.DB
+	distanceRepo := repository.NewDistanceRepo(db)
 
-	updateDistanceUserData := &model.UpdateDistanceUserData{
-		Distance: req.Distance,
+	vehicleType := vehicleTypeName(req.VehicleType)
+	points := config.AppConfig.Points
+	earn := &repository.PointsChange{
+		UserId:         req.Id,
+		Kind:           model.PointsEarn,
+		Points:         points.ForTrip(req.Distance, vehicleType),
+		IdempotencyKey: "trip:" + req.TripId,
+		Description:    "Trip " + req.TripId,
+		ExpiresAt:      time.Now().Add(points.ExpiresAfter),
 	}
 
-	db := config.DB
-	userRepo := repository.NewUserRepo(db)
-
-	// Updating the user's distance travelled in the database
-	if err := userRepo.UpdateDistanceTravelled(ctx, updateDistanceUserData, uint64(req.Id)); err != nil {
+	// Recording the trip in the ledger, adding it to the total and crediting its points
+	entry, err := distanceRepo.RecordTrip(ctx, req.Id, req.TripId, vehicleType, req.Distance, req.ExpectedVersion, earn)
+	if err != nil {
 		log.Println("Failed to update distance travelled:", err.Error())
 		return nil, err
 	}
 
-	return &pb.UpdateDistanceTravelledResponse{Message: "Distance updated successfully!"}, nil
+	// The trip is committed, so the follow-ups below only log their failures:
+	// the leaderboards are rebuilt from the ledger, the periodic evaluation
+	// catches up on tiers, and the next trip retries badges and rewards.
+	// A replayed trip is already on the leaderboards.
+	if !entry.Replayed {
+		recordOnLeaderboards(ctx, entry)
+		evaluateBadges(ctx, req.Id)
+		rewardReferral(ctx, req.Id)
+	}
+	evaluateMembership(ctx, req.Id)
+
+	return &pb.UpdateDistanceTravelledResponse{
+		Message:           "Distance updated successfully!",
+		Version:           entry.VersionAfter,
+		DistanceTravelled: entry.TotalAfter,
+		PointsEarned:      entry.PointsEarned,
+	}, nil
 }
 
 func (s *UserServiceServer) AuthenticateUser(ctx context.Context, req *pb.AuthenticateUserRequest) (*pb.AuthenticateUserResponse, error) {
-	if req.Token == "" {
-		return &pb.AuthenticateUserResponse{IsValid: false, Message: "Token is required"}, errors.New("Token is required")
-	}
-
-	parsedId, err := ParseToken(req.Token, os.Getenv("JWT_SECRET"))
+	parsedId, err := ParseToken(req.Token, config.AppConfig.JWT.Secret, TokenTypeAccess)
 	if err != nil {
 		log.Println("Failed to parse token:", err.Error())
-		return &pb.AuthenticateUserResponse{IsValid: false, Message: err.Error()}, err
+		return &pb.AuthenticateUserResponse{IsValid: false, Message: err.Error()}, apperror.Unauthenticated(apperror.ReasonInvalidToken, err.Error())
 	}
 	log.Printf("Extracted claims ID: %v", parsedId)
 
@@ -281,7 +508,7 @@ func (s *UserServiceServer) AuthenticateUser(ctx context.Context, req *pb.Authen
 	err = config.DB.Model(&model.User{}).Where("id = ?", parsedId).First(user).Error
 	if err != nil || reflect.DeepEqual(user, &pb.User{}) {
 		log.Println("Failed to get users:", err.Error())
-		return &pb.AuthenticateUserResponse{IsValid: false, Message: "Invalid Credentials!"}, errors.New("Invalid credentials")
+		return &pb.AuthenticateUserResponse{IsValid: false, Message: "Invalid Credentials!"}, apperror.Unauthenticated(apperror.ReasonInvalidToken, "Invalid credentials")
 	}
 
 	log.Printf("User found with ID: %v", user.Id)
@@ -289,15 +516,33 @@ func (s *UserServiceServer) AuthenticateUser(ctx context.Context, req *pb.Authen
 }
 
 func (s *UserServiceServer) RefreshToken (ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	userId, err := ParseToken(req.RefreshToken, config.AppConfig.JWT.Secret, TokenTypeRefresh)
+	if err != nil {
+		return nil, apperror.Unauthenticated(apperror.ReasonInvalidToken, err.Error())
+	}
+
 	rdb := config.Redis
 	sessionCache := cache.NewSessionCache(rdb)
 
-	userId, err := sessionCache.GetUserIdFromRefreshToken(ctx, req.RefreshToken)
+	// Logging out deletes the session, which revokes the refresh token
+	session, err := sessionCache.GetSession(ctx, req.RefreshToken)
+	if errors.Is(err, redis.Nil) || (err == nil && session.UserId != uint64(userId)) {
+		return nil, apperror.Unauthenticated(apperror.ReasonSessionExpired, "Refresh token is invalid or expired")
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	newAccessToken, err := GenerateToken(userId, 15*time.Minute)
+	// Every refresh slides the idle timeout, up to the session's max age
+	if err := sessionCache.TouchSession(ctx, req.RefreshToken, session); err != nil {
+		if errors.Is(err, cache.ErrSessionExpired) {
+			return nil, apperror.Unauthenticated(apperror.ReasonSessionExpired, "Session has reached its maximum age, please log in again")
+		}
+		return nil, err
+	}
+
+	policy := config.AppConfig.Session.Policy(session.ClientType, session.RememberMe)
+	newAccessToken, err := GenerateToken(session.UserId, TokenTypeAccess, policy.AccessTokenTTL)
 	if err != nil {
 		return nil, err
 	}
@@ -305,6 +550,66 @@ func (s *UserServiceServer) RefreshToken (ctx context.Context, req *pb.RefreshTo
 	return &pb.RefreshTokenResponse{AccessToken: newAccessToken}, nil
 }
 
+// Returns the E.164 form of a phone number, reading national numbers in the configured default region
+func normalizePhoneNumber(field, raw string) (string, error) {
+	normalized, err := config.AppConfig.Phone.Normalize(raw)
+	if err != nil {
+		return "", apperror.InvalidArgument("Invalid phone number",
+			apperror.FieldViolation{Field: field, Description: "must be a valid phone number, e.g. +6591234567"})
+	}
+	return normalized, nil
+}
+
+// Returns the fields UpdateUser has to change: the update_mask paths, or
+// every non-empty field when there is no mask. Masked fields cannot be empty.
+func updateUserPaths(req *pb.UpdateUserRequest) ([]string, error) {
+	values := map[string]string{
+		"name":         req.Name,
+		"phone_number": req.PhoneNumber,
+		"email":        req.Email,
+		"time_zone":    req.TimeZone,
+		"city":         req.City,
+	}
+
+	if len(req.UpdateMask.GetPaths()) == 0 {
+		var paths []string
+		for _, path := range []string{"name", "phone_number", "email", "time_zone", "city"} {
+			if values[path] != "" {
+				paths = append(paths, path)
+			}
+		}
+		if len(paths) == 0 {
+			return nil, apperror.InvalidArgument("Nothing to update",
+				apperror.FieldViolation{Field: "update_mask", Description: "must name at least one field, or set one"})
+		}
+		return paths, nil
+	}
+
+	var violations []apperror.FieldViolation
+	for _, path := range req.UpdateMask.Paths {
+		if values[path] == "" {
+			violations = append(violations, apperror.FieldViolation{Field: path, Description: "is required when listed in update_mask"})
+		}
+	}
+	if len(violations) > 0 {
+		return nil, apperror.InvalidArgument("Request has invalid fields", violations...)
+	}
+
+	return req.UpdateMask.Paths, nil
+}
+
+// Maps the proto client type to the name used by the session policies
+func clientTypeName(clientType pb.ClientType) string {
+	switch clientType {
+	case pb.ClientType_CLIENT_TYPE_MOBILE_APP:
+		return config.ClientTypeMobileApp
+	case pb.ClientType_CLIENT_TYPE_DRIVER_APP:
+		return config.ClientTypeDriverApp
+	default:
+		return config.ClientTypeWeb
+	}
+}
+
 // func GetUserById(db *gorm.DB) func(c *gin.Context) {
 // 	return func(c *gin.Context) {
 // 		var data model.User
@@ -0,0 +1,96 @@
+// Package streak counts the consecutive days and weeks in which a user took
+// trips, in the user's time zone.
+package streak
+
+import (
+	"context"
+	"time"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/model"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/repository"
+)
+
+// Streak is where a user's streaks stand at a point in time
+type Streak struct {
+	// Consecutive days with a trip, up to today or yesterday
+	Daily int
+	// Consecutive ISO weeks with a trip, up to this week or the previous one
+	Weekly int
+	// Whether the user took a trip today
+	TripToday bool
+	// Nil when the user took no trip within the lookback
+	LastTripAt *time.Time
+}
+
+// AtRisk reports whether the daily streak ends at midnight unless the user
+// takes a trip today
+func (s Streak) AtRisk() bool {
+	return s.Daily > 0 && !s.TripToday
+}
+
+// Load computes the user's streaks from the trips of the last lookback, the
+// longest streak it can count
+func Load(ctx context.Context, user *model.User, now time.Time, lookback time.Duration) (Streak, error) {
+	distanceRepo := repository.NewDistanceRepo(config.DB)
+
+	trips, err := distanceRepo.TripTimes(ctx, user.Id, now.Add(-lookback))
+	if err != nil {
+		return Streak{}, err
+	}
+
+	return Compute(trips, now.In(user.Location())), nil
+}
+
+// Compute counts the streaks of trips, newest first, in the location of now
+func Compute(trips []time.Time, now time.Time) Streak {
+	loc := now.Location()
+	days := make(map[string]bool, len(trips))
+	weeks := make(map[string]bool)
+	for _, trip := range trips {
+		day := startOfDay(trip.In(loc))
+		days[dateKey(day)] = true
+		weeks[dateKey(startOfWeek(day))] = true
+	}
+
+	today := startOfDay(now)
+	s := Streak{TripToday: days[dateKey(today)]}
+	if len(trips) > 0 {
+		s.LastTripAt = &trips[0]
+	}
+
+	// A streak is only broken once a whole day or week passed without a trip
+	day := today
+	if !days[dateKey(day)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for days[dateKey(day)] {
+		s.Daily++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	week := startOfWeek(today)
+	if !weeks[dateKey(week)] {
+		week = week.AddDate(0, 0, -7)
+	}
+	for weeks[dateKey(week)] {
+		s.Weekly++
+		week = week.AddDate(0, 0, -7)
+	}
+
+	return s
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// Weeks start on Monday, like ISO weeks
+func startOfWeek(day time.Time) time.Time {
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+func dateKey(day time.Time) string {
+	return day.Format("2006-01-02")
+}
@@ -0,0 +1,99 @@
+package streak
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompute(t *testing.T) {
+	// Wednesday, in a time zone ahead of UTC
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	now := time.Date(2026, 10, 14, 20, 0, 0, 0, loc)
+
+	tests := []struct {
+		name      string
+		trips     []string
+		daily     int
+		weekly    int
+		tripToday bool
+		atRisk    bool
+	}{
+		{
+			name: "no trips",
+		},
+		{
+			name:      "trip today",
+			trips:     []string{"2026-10-14T09:00:00+08:00"},
+			daily:     1,
+			weekly:    1,
+			tripToday: true,
+		},
+		{
+			name:   "streak up to yesterday is at risk",
+			trips:  []string{"2026-10-13T09:00:00+08:00", "2026-10-12T09:00:00+08:00"},
+			daily:  2,
+			weekly: 1,
+			atRisk: true,
+		},
+		{
+			name:      "several trips a day count once",
+			trips:     []string{"2026-10-14T18:00:00+08:00", "2026-10-14T08:00:00+08:00", "2026-10-13T08:00:00+08:00"},
+			daily:     2,
+			weekly:    1,
+			tripToday: true,
+		},
+		{
+			name:      "missed day breaks the daily streak",
+			trips:     []string{"2026-10-14T09:00:00+08:00", "2026-10-13T09:00:00+08:00", "2026-10-11T09:00:00+08:00"},
+			daily:     2,
+			weekly:    2,
+			tripToday: true,
+		},
+		{
+			name:   "trip two days ago",
+			trips:  []string{"2026-10-12T09:00:00+08:00"},
+			weekly: 1,
+		},
+		{
+			name:   "weekly streak up to the previous week",
+			trips:  []string{"2026-10-08T09:00:00+08:00", "2026-10-01T09:00:00+08:00", "2026-09-20T09:00:00+08:00"},
+			weekly: 2,
+		},
+		{
+			name:      "days are counted in the time zone of now",
+			trips:     []string{"2026-10-13T17:00:00Z"},
+			daily:     1,
+			weekly:    1,
+			tripToday: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var trips []time.Time
+			for _, trip := range test.trips {
+				at, err := time.Parse(time.RFC3339, trip)
+				if err != nil {
+					t.Fatal(err)
+				}
+				trips = append(trips, at)
+			}
+
+			s := Compute(trips, now)
+
+			if s.Daily != test.daily || s.Weekly != test.weekly || s.TripToday != test.tripToday {
+				t.Errorf("Compute() = daily %d, weekly %d, trip today %v, want %d, %d, %v",
+					s.Daily, s.Weekly, s.TripToday, test.daily, test.weekly, test.tripToday)
+			}
+			if s.AtRisk() != test.atRisk {
+				t.Errorf("AtRisk() = %v, want %v", s.AtRisk(), test.atRisk)
+			}
+			if len(trips) == 0 && s.LastTripAt != nil {
+				t.Errorf("LastTripAt = %v, want nil", s.LastTripAt)
+			}
+			if len(trips) > 0 && (s.LastTripAt == nil || !s.LastTripAt.Equal(trips[0])) {
+				t.Errorf("LastTripAt = %v, want %v", s.LastTripAt, trips[0])
+			}
+		})
+	}
+}
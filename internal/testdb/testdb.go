@@ -0,0 +1,102 @@
+// Package testdb gives tests a MySQL database with the service's schema.
+package testdb
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	gormmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Server the test databases are created on, e.g. root:secret@tcp(localhost:3306)/
+const dsnEnv = "TEST_MYSQL_DSN"
+
+// Open creates a database on the TEST_MYSQL_DSN server, applies every up
+// migration to it and drops it once the test ends. The test is skipped when
+// TEST_MYSQL_DSN is not set.
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv(dsnEnv)
+	if dsn == "" {
+		t.Skip(dsnEnv + " is not set")
+	}
+
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		t.Fatalf("%s: %v", dsnEnv, err)
+	}
+	cfg.DBName = ""
+	cfg.ParseTime = true
+	// As the service connects
+	cfg.Loc = time.UTC
+	cfg.MultiStatements = true
+
+	server, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	cfg.DBName = "eco_taxi_test_" + randomSuffix(t)
+	if _, err := server.Exec("CREATE DATABASE " + cfg.DBName); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if _, err := server.Exec("DROP DATABASE " + cfg.DBName); err != nil {
+			t.Errorf("Failed to drop %s: %v", cfg.DBName, err)
+		}
+	})
+
+	db, err := gorm.Open(gormmysql.Open(cfg.FormatDSN()), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrate(t, sqlDB)
+
+	return db
+}
+
+// Applies the up migrations in order, as golang-migrate does
+func migrate(t testing.TB, db *sql.DB) {
+	t.Helper()
+
+	_, file, _, _ := runtime.Caller(0)
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(file), "..", "script", "migrations", "*.up.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range files {
+		migration, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(string(migration)); err != nil {
+			t.Fatalf("%s: %v", filepath.Base(name), err)
+		}
+	}
+}
+
+func randomSuffix(t testing.TB) string {
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(suffix)
+}
@@ -2,23 +2,25 @@ package utils
 
 import (
 	"log"
-	"os"
 
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
 	"gopkg.in/gomail.v2"
 )
 
 func SendEmail(to, subject, body string) error {
+	smtp := config.AppConfig.SMTP
+
 	m := gomail.NewMessage()
-	m.SetHeader("From", os.Getenv("EMAIL_SENDER"))
+	m.SetHeader("From", smtp.Sender)
 	m.SetHeader("To", to)
 	m.SetHeader("Subject", subject)
 	m.SetBody("text/html", body)
 
 	d := gomail.NewDialer(
-		os.Getenv("SMTP_HOST"),
-		587, // Change if your SMTP server uses a different port
-		os.Getenv("SMTP_USER"),
-		os.Getenv("SMTP_PASSWORD"),
+		smtp.Host,
+		smtp.Port,
+		smtp.User,
+		smtp.Password,
 	)
 
 	if err := d.DialAndSend(m); err != nil {
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+)
+
+// Sends a text message through the configured gateway, or logs it when there is none
+func SendSMS(to, body string) error {
+	sms := config.AppConfig.SMS
+
+	if sms.GatewayURL == "" {
+		log.Printf("SMS gateway not configured, SMS to %s: %s", to, body)
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"from": sms.Sender,
+		"to":   to,
+		"body": body,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sms.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sms.GatewayURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+sms.APIKey)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Println("Failed to send SMS:", err)
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		log.Println("Failed to send SMS, gateway responded with", res.Status)
+		return fmt.Errorf("SMS gateway responded with %s", res.Status)
+	}
+	return nil
+}
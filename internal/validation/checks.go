@@ -0,0 +1,142 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Every check but Required accepts the zero value, so optional fields are
+// only validated when they are set.
+
+func Required() Check {
+	return func(v protoreflect.Value) string {
+		switch value := v.Interface().(type) {
+		case string:
+			if value == "" {
+				return "is required"
+			}
+		case uint64:
+			if value == 0 {
+				return "is required"
+			}
+		case float64:
+			if value == 0 {
+				return "is required"
+			}
+		case int64:
+			if value == 0 {
+				return "is required"
+			}
+		case protoreflect.EnumNumber:
+			if value == 0 {
+				return "is required"
+			}
+		}
+		return ""
+	}
+}
+
+// Length bounds the number of characters of a string
+func Length(min, max int) Check {
+	return func(v protoreflect.Value) string {
+		n := utf8.RuneCountInString(v.String())
+		if n == 0 || (n >= min && n <= max) {
+			return ""
+		}
+		return fmt.Sprintf("must be between %d and %d characters", min, max)
+	}
+}
+
+func Email() Check {
+	return func(v protoreflect.Value) string {
+		value := v.String()
+		if value == "" {
+			return ""
+		}
+		// ParseAddress also accepts display names ("Jane <jane@x.com>"), which are not emails
+		if address, err := mail.ParseAddress(value); err != nil || address.Address != value {
+			return "must be a valid email address"
+		}
+		return ""
+	}
+}
+
+// Phone accepts numbers that normalize to E.164, national numbers being read
+// in the configured default region
+func Phone() Check {
+	return func(v protoreflect.Value) string {
+		value := v.String()
+		if value == "" {
+			return ""
+		}
+		if _, err := config.AppConfig.Phone.Normalize(value); err != nil {
+			return "must be a valid phone number, e.g. +6591234567"
+		}
+		return ""
+	}
+}
+
+// TimeZone requires an IANA time zone name, e.g. Asia/Singapore
+func TimeZone() Check {
+	return func(v protoreflect.Value) string {
+		value := v.String()
+		if value == "" {
+			return ""
+		}
+		// LoadLocation also accepts "Local", which means nothing to the client
+		if _, err := time.LoadLocation(value); err != nil || value == "Local" {
+			return "must be an IANA time zone, e.g. Asia/Singapore"
+		}
+		return ""
+	}
+}
+
+// Range bounds a number, excluding min itself when exclusiveMin is set
+func Range(min, max float64, exclusiveMin bool) Check {
+	return func(v protoreflect.Value) string {
+		var value float64
+		switch n := v.Interface().(type) {
+		case int32:
+			value = float64(n)
+		case int64:
+			value = float64(n)
+		default:
+			value = v.Float()
+		}
+		if math.IsNaN(value) {
+			return "must be a number"
+		}
+		if exclusiveMin && value <= min {
+			return fmt.Sprintf("must be greater than %g", min)
+		}
+		if value < min {
+			return fmt.Sprintf("must be at least %g", min)
+		}
+		if value > max {
+			return fmt.Sprintf("must be at most %g", max)
+		}
+		return ""
+	}
+}
+
+// Paths restricts the paths of a google.protobuf.FieldMask to the allowed ones
+func Paths(allowed ...string) Check {
+	return func(v protoreflect.Value) string {
+		mask := v.Message()
+		paths := mask.Get(mask.Descriptor().Fields().ByName("paths")).List()
+		for i := 0; i < paths.Len(); i++ {
+			if path := paths.Get(i).String(); !slices.Contains(allowed, path) {
+				return fmt.Sprintf("cannot contain %q, allowed paths are %s", path, strings.Join(allowed, ", "))
+			}
+		}
+		return ""
+	}
+}
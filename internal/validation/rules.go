@@ -0,0 +1,275 @@
+package validation
+
+import (
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+)
+
+const (
+	maxNameLength     = 50
+	maxEmailLength    = 50
+	maxCityLength     = 64
+	minPasswordLength = 8
+	// bcrypt ignores everything after 72 bytes
+	maxPasswordLength = 72
+	// Longest single trip the service accepts, in km
+	maxTripDistance = 1000
+	maxTripIdLength = 64
+	// Largest correction an admin can make at once, in km
+	maxDistanceAdjustment = 100000
+	maxReasonLength       = 255
+	// Most points redeemed or adjusted at once
+	maxPointsChange         = 1000000
+	maxIdempotencyKeyLength = 64
+	// Largest monthly goal, in km or grams of CO2
+	maxGoalTarget         = 100000000
+	maxReferralCodeLength = 16
+	maxDeviceIdLength     = 64
+	maxPlaceLabelLength   = 50
+	maxAddressLength      = 255
+	maxRelationshipLength = 32
+)
+
+func init() {
+	Register(&pb.SignUpRequest{},
+		Field("name", Required(), Length(1, maxNameLength)),
+		Field("phone_number", Required(), Phone()),
+		Field("email", Required(), Email(), Length(1, maxEmailLength)),
+		Field("password", Required(), Length(minPasswordLength, maxPasswordLength)),
+		Field("referral_code", Length(1, maxReferralCodeLength)),
+		Field("device_id", Length(1, maxDeviceIdLength)),
+	)
+
+	Register(&pb.LogInRequest{},
+		Field("phone_number", Required(), Phone()),
+		Field("password", Required()),
+	)
+
+	Register(&pb.LogOutRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.ForgotPasswordRequest{},
+		Field("email", Required(), Email()),
+	)
+
+	Register(&pb.ResetPasswordRequest{},
+		Field("email", Required(), Email()),
+		Field("code", Required(), Length(config.VerificationCodeLength, config.VerificationCodeLength)),
+		Field("new_password", Required(), Length(minPasswordLength, maxPasswordLength)),
+	)
+
+	Register(&pb.UpdateUserRequest{},
+		Field("id", Required()),
+		Field("name", Length(1, maxNameLength)),
+		Field("phone_number", Phone()),
+		Field("email", Email(), Length(1, maxEmailLength)),
+		Field("time_zone", TimeZone()),
+		Field("city", Length(1, maxCityLength)),
+		Field("update_mask", Paths("name", "phone_number", "email", "time_zone", "city")),
+	)
+
+	Register(&pb.RequestEmailChangeRequest{},
+		Field("id", Required()),
+		Field("email", Required(), Email(), Length(1, maxEmailLength)),
+	)
+
+	Register(&pb.ConfirmEmailChangeRequest{},
+		Field("id", Required()),
+		Field("code", Required(), Length(config.VerificationCodeLength, config.VerificationCodeLength)),
+	)
+
+	Register(&pb.RequestPhoneChangeRequest{},
+		Field("id", Required()),
+		Field("phone_number", Required(), Phone()),
+	)
+
+	Register(&pb.ConfirmPhoneChangeRequest{},
+		Field("id", Required()),
+		Field("code", Required(), Length(config.VerificationCodeLength, config.VerificationCodeLength)),
+	)
+
+	Register(&pb.RevertContactChangeRequest{},
+		Field("token", Required()),
+	)
+
+	Register(&pb.GetUserRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.ChangePasswordRequest{},
+		Field("id", Required()),
+		Field("old_password", Required()),
+		Field("new_password", Required(), Length(minPasswordLength, maxPasswordLength)),
+	)
+
+	Register(&pb.UpdateDistanceTravelledRequest{},
+		Field("id", Required()),
+		Field("distance", Range(0, maxTripDistance, true)),
+		Field("trip_id", Required(), Length(1, maxTripIdLength)),
+	)
+
+	Register(&pb.ListDistanceEntriesRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.AdjustDistanceRequest{},
+		Field("id", Required()),
+		Field("distance", Required(), Range(-maxDistanceAdjustment, maxDistanceAdjustment, false)),
+		Field("reason", Required(), Length(1, maxReasonLength)),
+	)
+
+	Register(&pb.GetEcoImpactRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.GetPointsBalanceRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.ListPointsTransactionsRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.RedeemPointsRequest{},
+		Field("id", Required()),
+		Field("points", Required(), Range(0, maxPointsChange, true)),
+		Field("idempotency_key", Required(), Length(1, maxIdempotencyKeyLength)),
+		Field("description", Length(1, maxReasonLength)),
+	)
+
+	Register(&pb.AdjustPointsRequest{},
+		Field("id", Required()),
+		Field("points", Required(), Range(-maxPointsChange, maxPointsChange, false)),
+		Field("idempotency_key", Required(), Length(1, maxIdempotencyKeyLength)),
+		Field("reason", Required(), Length(1, maxReasonLength)),
+	)
+
+	Register(&pb.GetMembershipRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.GetLeaderboardRequest{},
+		Field("board", Required()),
+		Field("city", Length(1, maxCityLength)),
+	)
+
+	Register(&pb.GetMyRankRequest{},
+		Field("id", Required()),
+		Field("board", Required()),
+	)
+
+	Register(&pb.SetLeaderboardOptOutRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.ListMyBadgesRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.SetGoalRequest{},
+		Field("id", Required()),
+		Field("metric", Required()),
+		Field("target", Required(), Range(0, maxGoalTarget, true)),
+	)
+
+	Register(&pb.GetGoalProgressRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.GetReferralStatsRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.GenerateEcoReportRequest{},
+		Field("id", Required()),
+		Field("format", Required()),
+	)
+
+	Register(&pb.CreateSavedPlaceRequest{},
+		Field("id", Required()),
+		Field("label", Required(), Length(1, maxPlaceLabelLength)),
+		Field("address", Required(), Length(1, maxAddressLength)),
+		Field("latitude", Range(-90, 90, false)),
+		Field("longitude", Range(-180, 180, false)),
+		Field("place_type", Required()),
+	)
+
+	Register(&pb.ListSavedPlacesRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.UpdateSavedPlaceRequest{},
+		Field("id", Required()),
+		Field("place_id", Required()),
+		Field("label", Length(1, maxPlaceLabelLength)),
+		Field("address", Length(1, maxAddressLength)),
+		Field("latitude", Range(-90, 90, false)),
+		Field("longitude", Range(-180, 180, false)),
+		Field("update_mask", Paths("label", "address", "latitude", "longitude", "place_type")),
+	)
+
+	Register(&pb.DeleteSavedPlaceRequest{},
+		Field("id", Required()),
+		Field("place_id", Required()),
+	)
+
+	Register(&pb.CreateEmergencyContactRequest{},
+		Field("id", Required()),
+		Field("name", Required(), Length(1, maxNameLength)),
+		Field("phone_number", Required(), Phone()),
+		Field("relationship", Length(1, maxRelationshipLength)),
+	)
+
+	Register(&pb.ListEmergencyContactsRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.UpdateEmergencyContactRequest{},
+		Field("id", Required()),
+		Field("contact_id", Required()),
+		Field("name", Length(1, maxNameLength)),
+		Field("phone_number", Phone()),
+		Field("relationship", Length(1, maxRelationshipLength)),
+		Field("update_mask", Paths("name", "phone_number", "relationship")),
+	)
+
+	Register(&pb.DeleteEmergencyContactRequest{},
+		Field("id", Required()),
+		Field("contact_id", Required()),
+	)
+
+	Register(&pb.VerifyEmergencyContactRequest{},
+		Field("id", Required()),
+		Field("contact_id", Required()),
+		Field("code", Required(), Length(config.VerificationCodeLength, config.VerificationCodeLength)),
+	)
+
+	Register(&pb.ResendEmergencyContactCodeRequest{},
+		Field("id", Required()),
+		Field("contact_id", Required()),
+	)
+
+	Register(&pb.GetSafetyProfileRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.GetPreferencesRequest{},
+		Field("id", Required()),
+	)
+
+	Register(&pb.UpdatePreferencesRequest{},
+		Field("id", Required()),
+		Field("update_mask", Paths("language", "distance_unit", "quiet_ride", "ev_only",
+			"wheelchair_accessible", "service_animal", "hearing_impaired",
+			"streak_reminders", "marketing_emails", "marketing_sms")),
+	)
+
+	Register(&pb.AuthenticateUserRequest{},
+		Field("token", Required()),
+	)
+
+	Register(&pb.RefreshTokenRequest{},
+		Field("refresh_token", Required()),
+	)
+}
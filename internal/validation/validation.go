@@ -0,0 +1,73 @@
+// Package validation checks incoming requests against rules declared per
+// proto message, reporting every violation at once.
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/apperror"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Check inspects one field value and returns a description of what is wrong
+// with it, or "" when it is valid
+type Check func(v protoreflect.Value) string
+
+// FieldRule binds checks to a field of a message
+type FieldRule struct {
+	field  protoreflect.Name
+	checks []Check
+}
+
+// Field declares the checks of one field, run in order until one fails
+func Field(name string, checks ...Check) FieldRule {
+	return FieldRule{field: protoreflect.Name(name), checks: checks}
+}
+
+var registry = map[protoreflect.FullName][]FieldRule{}
+
+// Register declares the rules of a message type. It panics on unknown field
+// names so typos are caught at startup.
+func Register(msg proto.Message, rules ...FieldRule) {
+	desc := msg.ProtoReflect().Descriptor()
+	for _, rule := range rules {
+		if desc.Fields().ByName(rule.field) == nil {
+			panic(fmt.Sprintf("validation: %s has no field %q", desc.FullName(), rule.field))
+		}
+	}
+	registry[desc.FullName()] = append(registry[desc.FullName()], rules...)
+}
+
+// Validate returns one violation per invalid field of msg
+func Validate(msg proto.Message) []apperror.FieldViolation {
+	m := msg.ProtoReflect()
+	var violations []apperror.FieldViolation
+
+	for _, rule := range registry[m.Descriptor().FullName()] {
+		fd := m.Descriptor().Fields().ByName(rule.field)
+		value := m.Get(fd)
+
+		for _, check := range rule.checks {
+			if description := check(value); description != "" {
+				violations = append(violations, apperror.FieldViolation{Field: string(rule.field), Description: description})
+				break
+			}
+		}
+	}
+
+	return violations
+}
+
+// Rejects requests breaking their registered rules with a single
+// InvalidArgument error listing every violation
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if msg, ok := req.(proto.Message); ok {
+		if violations := Validate(msg); len(violations) > 0 {
+			return nil, apperror.InvalidArgument("Request has invalid fields", violations...)
+		}
+	}
+	return handler(ctx, req)
+}
@@ -0,0 +1,127 @@
+package validation
+
+import (
+	"math"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/haiyen11231/eco-taxi-backend-user-service/config"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/grpc/pb"
+	"github.com/haiyen11231/eco-taxi-backend-user-service/internal/phone"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestMain(m *testing.M) {
+	config.AppConfig = &config.Config{
+		Phone: config.PhoneConfig{
+			DefaultRegion: "SG",
+			Regions: map[string]phone.Region{
+				"SG": {CallingCode: "65", MinLength: 8, MaxLength: 8},
+			},
+		},
+	}
+	os.Exit(m.Run())
+}
+
+func TestChecks(t *testing.T) {
+	tests := []struct {
+		name  string
+		check Check
+		value protoreflect.Value
+		valid bool
+	}{
+		{"required string", Required(), protoreflect.ValueOfString("a"), true},
+		{"required empty string", Required(), protoreflect.ValueOfString(""), false},
+		{"required id", Required(), protoreflect.ValueOfUint64(1), true},
+		{"required zero id", Required(), protoreflect.ValueOfUint64(0), false},
+		{"required zero float", Required(), protoreflect.ValueOfFloat64(0), false},
+		{"required zero int", Required(), protoreflect.ValueOfInt64(0), false},
+		{"required zero enum", Required(), protoreflect.ValueOfEnum(0), false},
+
+		{"length empty", Length(2, 4), protoreflect.ValueOfString(""), true},
+		{"length within", Length(2, 4), protoreflect.ValueOfString("abc"), true},
+		{"length too short", Length(2, 4), protoreflect.ValueOfString("a"), false},
+		{"length too long", Length(2, 4), protoreflect.ValueOfString("abcde"), false},
+		{"length counts characters", Length(2, 3), protoreflect.ValueOfString("Hải"), true},
+
+		{"email empty", Email(), protoreflect.ValueOfString(""), true},
+		{"email", Email(), protoreflect.ValueOfString("jane@example.com"), true},
+		{"email without domain", Email(), protoreflect.ValueOfString("jane"), false},
+		{"email with display name", Email(), protoreflect.ValueOfString("Jane <jane@example.com>"), false},
+
+		{"phone empty", Phone(), protoreflect.ValueOfString(""), true},
+		{"phone national", Phone(), protoreflect.ValueOfString("9123 4567"), true},
+		{"phone international", Phone(), protoreflect.ValueOfString("+84 912 345 678"), true},
+		{"phone too short", Phone(), protoreflect.ValueOfString("91234"), false},
+		{"phone letters", Phone(), protoreflect.ValueOfString("9123abcd"), false},
+
+		{"time zone empty", TimeZone(), protoreflect.ValueOfString(""), true},
+		{"time zone", TimeZone(), protoreflect.ValueOfString("Asia/Singapore"), true},
+		{"time zone local", TimeZone(), protoreflect.ValueOfString("Local"), false},
+		{"time zone unknown", TimeZone(), protoreflect.ValueOfString("Mars/Olympus"), false},
+
+		{"range within", Range(0, 10, true), protoreflect.ValueOfFloat64(5), true},
+		{"range max", Range(0, 10, true), protoreflect.ValueOfFloat64(10), true},
+		{"range exclusive min", Range(0, 10, true), protoreflect.ValueOfFloat64(0), false},
+		{"range inclusive min", Range(0, 10, false), protoreflect.ValueOfFloat64(0), true},
+		{"range below min", Range(-5, 5, false), protoreflect.ValueOfFloat64(-6), false},
+		{"range above max", Range(0, 10, false), protoreflect.ValueOfInt64(11), false},
+		{"range NaN", Range(0, 10, false), protoreflect.ValueOfFloat64(math.NaN()), false},
+
+		{"paths allowed", Paths("name", "email"), maskValue("name", "email"), true},
+		{"paths none", Paths("name"), maskValue(), true},
+		{"paths unknown", Paths("name"), maskValue("name", "password"), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			description := test.check(test.value)
+			if valid := description == ""; valid != test.valid {
+				t.Errorf("valid = %v (%q), want %v", valid, description, test.valid)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    *pb.SignUpRequest
+		fields []string
+	}{
+		{
+			name: "valid",
+			req:  &pb.SignUpRequest{Name: "Jane", PhoneNumber: "91234567", Email: "jane@example.com", Password: "password"},
+		},
+		{
+			name:   "missing fields",
+			req:    &pb.SignUpRequest{Name: "Jane"},
+			fields: []string{"phone_number", "email", "password"},
+		},
+		{
+			name:   "invalid fields",
+			req:    &pb.SignUpRequest{Name: strings.Repeat("a", maxNameLength+1), PhoneNumber: "1", Email: "jane", Password: "short"},
+			fields: []string{"name", "phone_number", "email", "password"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations := Validate(test.req)
+
+			var fields []string
+			for _, violation := range violations {
+				fields = append(fields, violation.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(test.fields, ",") {
+				t.Errorf("violations on %v, want %v", fields, test.fields)
+			}
+		})
+	}
+}
+
+func maskValue(paths ...string) protoreflect.Value {
+	return protoreflect.ValueOfMessage((&fieldmaskpb.FieldMask{Paths: paths}).ProtoReflect())
+}